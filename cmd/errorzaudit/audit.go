@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ibrt/golang-errors/internal/loader"
+)
+
+const errorzPkgPath = "github.com/ibrt/golang-errors/errorz"
+
+// Inventory describes the usage of error ids across a set of packages.
+type Inventory struct {
+	IDs    []*IDUsage `json:"ids"`
+	Issues []string   `json:"issues,omitempty"`
+}
+
+// IDUsage describes the usage of a single error id.
+type IDUsage struct {
+	ID           string   `json:"id"`
+	Statuses     []int    `json:"statuses,omitempty"`
+	Locations    []string `json:"locations,omitempty"`
+	Declarations []string `json:"declarations,omitempty"`
+	MetadataKeys []string `json:"metadataKeys,omitempty"`
+	InCatalog    bool     `json:"inCatalog,omitempty"`
+}
+
+type auditor struct {
+	fset  *token.FileSet
+	usage map[string]*idUsage
+}
+
+type idUsage struct {
+	statuses     map[int]struct{}
+	locations    []token.Position
+	declarations []token.Position
+	metadataKeys map[string]struct{}
+	inCatalog    bool
+}
+
+func newAuditor(fset *token.FileSet) *auditor {
+	return &auditor{
+		fset:  fset,
+		usage: map[string]*idUsage{},
+	}
+}
+
+func (a *auditor) get(id string) *idUsage {
+	u, ok := a.usage[id]
+	if !ok {
+		u = &idUsage{
+			statuses:     map[int]struct{}{},
+			metadataKeys: map[string]struct{}{},
+		}
+		a.usage[id] = u
+	}
+	return u
+}
+
+// addCatalog adds ids which are expected to be used.
+func (a *auditor) addCatalog(ids []string) {
+	for _, id := range ids {
+		a.get(id).inCatalog = true
+	}
+}
+
+// addPackage collects id declarations and usage sites from the package.
+// A usage site is a call to a function accepting errorz options (e.g. errorz.Errorf) with a constant id argument, or
+// an errorz.Option slice literal with a constant id element (e.g. options later passed to errorz.Wrap).
+func (a *auditor) addPackage(pkg *loader.Package) {
+	for id, obj := range pkg.Info.Defs {
+		if c, ok := obj.(*types.Const); ok && isErrorzType(c.Type(), "ID") && c.Val().Kind() == constant.String {
+			u := a.get(constant.StringVal(c.Val()))
+			u.declarations = append(u.declarations, a.fset.Position(id.Pos()))
+		}
+	}
+
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if acceptsOptions(pkg.Info, n) {
+					a.addSite(pkg.Info, n.Pos(), n.Args)
+				}
+			case *ast.CompositeLit:
+				if isOptionSlice(pkg.Info.TypeOf(n)) {
+					a.addSite(pkg.Info, n.Pos(), n.Elts)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func (a *auditor) addSite(info *types.Info, pos token.Pos, args []ast.Expr) {
+	var ids []string
+	var statuses []int
+	var keys []string
+
+	for _, arg := range args {
+		tv := info.Types[arg]
+
+		switch {
+		case isErrorzType(tv.Type, "ID") && tv.Value != nil && tv.Value.Kind() == constant.String:
+			ids = append(ids, constant.StringVal(tv.Value))
+		case isErrorzType(tv.Type, "Status") && tv.Value != nil && tv.Value.Kind() == constant.Int:
+			if status, ok := constant.Int64Val(tv.Value); ok {
+				statuses = append(statuses, int(status))
+			}
+		default:
+			keys = append(keys, metadataKeys(info, arg)...)
+		}
+	}
+
+	for _, id := range ids {
+		u := a.get(id)
+		u.locations = append(u.locations, a.fset.Position(pos))
+		for _, status := range statuses {
+			u.statuses[status] = struct{}{}
+		}
+		for _, key := range keys {
+			u.metadataKeys[key] = struct{}{}
+		}
+	}
+}
+
+// inventory builds the inventory, flagging ids used with conflicting statuses and catalog ids which are never used.
+func (a *auditor) inventory() *Inventory {
+	inv := &Inventory{IDs: make([]*IDUsage, 0, len(a.usage))}
+
+	ids := make([]string, 0, len(a.usage))
+	for id := range a.usage {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		u := a.usage[id]
+		usage := &IDUsage{
+			ID:           id,
+			Statuses:     sortedInts(u.statuses),
+			Locations:    formatPositions(u.locations),
+			Declarations: formatPositions(u.declarations),
+			MetadataKeys: sortedStrings(u.metadataKeys),
+			InCatalog:    u.inCatalog,
+		}
+		inv.IDs = append(inv.IDs, usage)
+
+		if len(usage.Statuses) > 1 {
+			inv.Issues = append(inv.Issues, fmt.Sprintf("id %q is used with conflicting statuses: %v",
+				id, joinInts(usage.Statuses)))
+		}
+
+		if len(usage.Locations) == 0 {
+			if len(usage.Declarations) > 0 {
+				inv.Issues = append(inv.Issues, fmt.Sprintf("id %q is declared but never used (declared at %v)",
+					id, strings.Join(usage.Declarations, ", ")))
+			} else if usage.InCatalog {
+				inv.Issues = append(inv.Issues, fmt.Sprintf("catalog id %q is never used", id))
+			}
+		}
+
+		if len(usage.Declarations) > 1 {
+			inv.Issues = append(inv.Issues, fmt.Sprintf("id %q is declared multiple times: %v",
+				id, strings.Join(usage.Declarations, ", ")))
+		}
+	}
+
+	return inv
+}
+
+// String renders the inventory as text.
+func (inv *Inventory) String() string {
+	b := &strings.Builder{}
+
+	for _, u := range inv.IDs {
+		b.WriteString(u.ID)
+		if u.InCatalog {
+			b.WriteString(" (catalog)")
+		}
+		b.WriteString("\n")
+
+		if len(u.Statuses) > 0 {
+			fmt.Fprintf(b, "  statuses: %v\n", joinInts(u.Statuses))
+		}
+		if len(u.MetadataKeys) > 0 {
+			fmt.Fprintf(b, "  metadata keys: %v\n", strings.Join(u.MetadataKeys, ", "))
+		}
+		writeList(b, "declarations", u.Declarations)
+		writeList(b, "locations", u.Locations)
+	}
+
+	if len(inv.Issues) > 0 {
+		b.WriteString("\nissues:\n")
+		for _, issue := range inv.Issues {
+			fmt.Fprintf(b, "  %v\n", issue)
+		}
+	}
+
+	return b.String()
+}
+
+func writeList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "  %v:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(b, "    %v\n", item)
+	}
+}
+
+// acceptsOptions returns true if the called function is variadic with ...errorz.Option as last parameter.
+func acceptsOptions(info *types.Info, call *ast.CallExpr) bool {
+	sig, ok := info.TypeOf(call.Fun).(*types.Signature)
+	if !ok || !sig.Variadic() {
+		return false
+	}
+
+	return isOptionSlice(sig.Params().At(sig.Params().Len() - 1).Type())
+}
+
+// isOptionSlice returns true if the type is []errorz.Option.
+func isOptionSlice(t types.Type) bool {
+	slice, ok := t.(*types.Slice)
+	return ok && isErrorzType(slice.Elem(), "Option")
+}
+
+// metadataKeys returns the constant keys passed using errorz.M or errorz.Metadata literals.
+func metadataKeys(info *types.Info, arg ast.Expr) []string {
+	switch arg := arg.(type) {
+	case *ast.CallExpr:
+		if fn, ok := calleeObj(info, arg).(*types.Func); ok && fn.Pkg() != nil && fn.Pkg().Path() == errorzPkgPath && fn.Name() == "M" && len(arg.Args) > 0 {
+			if s, ok := constString(info, arg.Args[0]); ok {
+				return []string{s}
+			}
+		}
+	case *ast.CompositeLit:
+		if isErrorzType(info.TypeOf(arg), "Metadata") {
+			var keys []string
+			for _, elt := range arg.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if s, ok := constString(info, kv.Key); ok {
+						keys = append(keys, s)
+					}
+				}
+			}
+			return keys
+		}
+	}
+
+	return nil
+}
+
+func calleeObj(info *types.Info, call *ast.CallExpr) types.Object {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return info.Uses[fun]
+	case *ast.SelectorExpr:
+		return info.Uses[fun.Sel]
+	default:
+		return nil
+	}
+}
+
+func constString(info *types.Info, e ast.Expr) (string, bool) {
+	if tv, ok := info.Types[e]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true
+	}
+	return "", false
+}
+
+func isErrorzType(t types.Type, name string) bool {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		return obj.Pkg() != nil && obj.Pkg().Path() == errorzPkgPath && obj.Name() == name
+	}
+	return false
+}
+
+func formatPositions(positions []token.Position) []string {
+	sort.SliceStable(positions, func(i, j int) bool {
+		if positions[i].Filename != positions[j].Filename {
+			return positions[i].Filename < positions[j].Filename
+		}
+		return positions[i].Offset < positions[j].Offset
+	})
+
+	formatted := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		formatted = append(formatted, pos.String())
+	}
+	return formatted
+}
+
+func sortedInts(m map[int]struct{}) []int {
+	s := make([]int, 0, len(m))
+	for k := range m {
+		s = append(s, k)
+	}
+	sort.Ints(s)
+	return s
+}
+
+func sortedStrings(m map[string]struct{}) []string {
+	s := make([]string, 0, len(m))
+	for k := range m {
+		s = append(s, k)
+	}
+	sort.Strings(s)
+	return s
+}
+
+func joinInts(s []int) string {
+	parts := make([]string, 0, len(s))
+	for _, i := range s {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return strings.Join(parts, ", ")
+}
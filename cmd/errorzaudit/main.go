@@ -0,0 +1,121 @@
+// Command errorzaudit inventories the usage of error ids across Go packages. For each id it reports the statuses it
+// is used with, the locations of the usage sites, and the metadata keys attached alongside it. It also flags:
+//   - ids used with conflicting statuses;
+//   - ids declared as errorz.ID constants (or listed in the catalog file) but never used;
+//   - ids declared as errorz.ID constants more than once.
+//
+// Usage:
+//
+//	errorzaudit [-tests] [-json] [-catalog file] [-strict] [packages]
+//
+// Packages are given as directories, with the "/..." suffix for recursive matching (defaults to "./..."). The catalog
+// file lists one id per line (blank lines and lines starting with "#" are ignored). A usage site is a call to a
+// function accepting errorz options (e.g. errorz.Errorf or errorz.Wrap), or an errorz.Option slice literal, with a
+// constant id argument. With -strict, the exit status is 1 if any issue is flagged.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/internal/loader"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("errorzaudit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tests := flags.Bool("tests", false, "also audit test files")
+	asJSON := flags.Bool("json", false, "print the inventory as JSON")
+	catalog := flags.String("catalog", "", "file listing the expected ids, one per line")
+	strict := flags.Bool("strict", false, "exit with status 1 if any issue is flagged")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	dirs, err := loader.ExpandPatterns(patterns)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	l := loader.New(*tests)
+	a := newAuditor(l.Fset)
+
+	if *catalog != "" {
+		ids, err := readCatalog(*catalog)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		a.addCatalog(ids)
+	}
+
+	for _, dir := range dirs {
+		pkgs, err := l.LoadDir(dir)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		for _, pkg := range pkgs {
+			a.addPackage(pkg)
+		}
+	}
+
+	inv := a.inventory()
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(inv); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else {
+		fmt.Fprint(stdout, inv)
+	}
+
+	if *strict && len(inv.Issues) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func readCatalog(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errorz.Wrap(err)
+	}
+	defer errorz.IgnoreClose(f)
+
+	var ids []string
+	s := bufio.NewScanner(f)
+
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			ids = append(ids, line)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, errorz.Wrap(err, errorz.M("path", path))
+	}
+
+	return ids, nil
+}
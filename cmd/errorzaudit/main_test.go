@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	require.Equal(t, 0, run([]string{"-catalog", "./testdata/catalog.txt", "./testdata/a"}, stdout, stderr))
+	require.Empty(t, stderr.String())
+
+	file := filepath.Join("testdata", "a", "a.go")
+	require.Equal(t, strings.Join([]string{
+		"a.catalog-only (catalog)",
+		"a.conflict",
+		"  statuses: 409, 412",
+		"  metadata keys: attempt, op",
+		"  declarations:",
+		"    " + file + ":11:2",
+		"  locations:",
+		"    " + file + ":21:9",
+		"    " + file + ":25:9",
+		"a.literal",
+		"  locations:",
+		"    " + file + ":29:9",
+		"a.not-found (catalog)",
+		"  statuses: 404",
+		"  metadata keys: key",
+		"  declarations:",
+		"    " + file + ":10:2",
+		"    " + file + ":13:2",
+		"  locations:",
+		"    " + file + ":17:9",
+		"a.options",
+		"  statuses: 400",
+		"  metadata keys: field",
+		"  locations:",
+		"    " + file + ":37:13",
+		"a.unused",
+		"  declarations:",
+		"    " + file + ":12:2",
+		"",
+		"issues:",
+		`  catalog id "a.catalog-only" is never used`,
+		`  id "a.conflict" is used with conflicting statuses: 409, 412`,
+		`  id "a.not-found" is declared multiple times: ` + file + ":10:2, " + file + ":13:2",
+		`  id "a.unused" is declared but never used (declared at ` + file + ":12:2)",
+		"",
+	}, "\n"), stdout.String())
+}
+
+func TestRunJSON(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	require.Equal(t, 1, run([]string{"-json", "-strict", "./testdata/a"}, stdout, stderr))
+	require.Empty(t, stderr.String())
+
+	inv := &Inventory{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), inv))
+	require.Len(t, inv.IDs, 5)
+	require.Len(t, inv.Issues, 3)
+
+	require.Equal(t, &IDUsage{
+		ID:           "a.not-found",
+		Statuses:     []int{404},
+		Locations:    []string{filepath.Join("testdata", "a", "a.go") + ":17:9"},
+		Declarations: []string{filepath.Join("testdata", "a", "a.go") + ":10:2", filepath.Join("testdata", "a", "a.go") + ":13:2"},
+		MetadataKeys: []string{"key"},
+	}, inv.IDs[2])
+}
+
+func TestRunErrorzIDs(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	require.Equal(t, 1, run([]string{"-strict", "./testdata/b", "../../errorz"}, stdout, stderr))
+	require.Empty(t, stderr.String())
+	require.Contains(t, stdout.String(), strings.Join([]string{
+		"invalid-argument",
+		"  statuses: 400, 422",
+		"  declarations:",
+		"    " + filepath.Join("..", "..", "errorz", "validation.go") + ":15:7",
+	}, "\n"))
+	require.Contains(t, stdout.String(), `id "invalid-argument" is used with conflicting statuses: 400, 422`)
+}
+
+func TestRunClean(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	require.Equal(t, 0, run([]string{"-strict", "../../errorz/..."}, stdout, stderr))
+	require.Empty(t, stderr.String())
+}
+
+func TestRunErrors(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	require.Equal(t, 2, run([]string{"-unknown"}, &bytes.Buffer{}, stderr))
+	require.Contains(t, stderr.String(), "flag provided but not defined")
+
+	stderr.Reset()
+	require.Equal(t, 2, run([]string{"./does-not-exist"}, &bytes.Buffer{}, stderr))
+	require.NotEmpty(t, stderr.String())
+
+	stderr.Reset()
+	require.Equal(t, 2, run([]string{"-catalog", "./does-not-exist.txt", "./testdata/a"}, &bytes.Buffer{}, stderr))
+	require.NotEmpty(t, stderr.String())
+}
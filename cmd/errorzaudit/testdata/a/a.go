@@ -0,0 +1,39 @@
+package a
+
+import (
+	"net/http"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	NotFoundID   errorz.ID = "a.not-found"
+	ConflictID   errorz.ID = "a.conflict"
+	UnusedID     errorz.ID = "a.unused"
+	DuplicatedID errorz.ID = "a.not-found"
+)
+
+func Get(key string) error {
+	return errorz.Errorf("not found: %v", errorz.A(key), NotFoundID, errorz.Status(http.StatusNotFound), errorz.M("key", key))
+}
+
+func Put(err error) error {
+	return errorz.Wrap(err, ConflictID, errorz.Status(http.StatusConflict), errorz.Metadata{"attempt": 1, "op": "put"})
+}
+
+func Delete(err error) error {
+	return errorz.Wrap(err, ConflictID, errorz.Status(http.StatusPreconditionFailed))
+}
+
+func Literal(err error) error {
+	return errorz.MaybeWrap(err, errorz.ID("a.literal"))
+}
+
+func Dynamic(err error, id string) error {
+	return errorz.Wrap(err, errorz.ID(id), errorz.Status(http.StatusTeapot))
+}
+
+func Options(err error) error {
+	options := []errorz.Option{errorz.ID("a.options"), errorz.Status(http.StatusBadRequest), errorz.M("field", "x")}
+	return errorz.Wrap(err, options...)
+}
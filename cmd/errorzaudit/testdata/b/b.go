@@ -0,0 +1,11 @@
+package b
+
+import (
+	"net/http"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func Validate(err error) error {
+	return errorz.Wrap(err, errorz.InvalidArgumentID, errorz.Status(http.StatusUnprocessableEntity))
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const (
+	errorzPkgPath = "github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	// formatArgIndexes maps errorz functions accepting a format string to the index of the format argument.
+	formatArgIndexes = map[string]int{
+		"Errorf":     0,
+		"MustErrorf": 0,
+		"Assertf":    1,
+		"ErrorfCtx":  1,
+	}
+
+	// wrappingFuncs lists the errorz functions which create or wrap errors, applying options.
+	wrappingFuncs = map[string]struct{}{
+		"Wrap":             {},
+		"MaybeWrap":        {},
+		"MustWrap":         {},
+		"MaybeMustWrap":    {},
+		"WrapRecover":      {},
+		"MaybeWrapRecover": {},
+		"Errorf":           {},
+		"MustErrorf":       {},
+		"Assertf":          {},
+		"WrapCtx":          {},
+		"MaybeWrapCtx":     {},
+		"ErrorfCtx":        {},
+	}
+
+	// skipFuncs lists the errorz functions which capture the frame they are called from.
+	skipFuncs = map[string]struct{}{
+		"Skip":        {},
+		"SkipAll":     {},
+		"SkipPackage": {},
+	}
+
+	// errorConstructors lists the standard library functions which create new errors, rather than returning errors
+	// from an operation: their results don't need to be wrapped.
+	errorConstructors = map[string]struct{}{
+		"errors.New":  {},
+		"errors.Join": {},
+		"fmt.Errorf":  {},
+	}
+)
+
+// Diagnostic describes an issue found by the checker.
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+// String implements the fmt.Stringer interface.
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%v: %v", d.Pos, d.Message)
+}
+
+type checker struct {
+	fset  *token.FileSet
+	pkg   *types.Package
+	info  *types.Info
+	diags []*Diagnostic
+}
+
+// check runs all checks on the given type-checked files.
+func check(fset *token.FileSet, pkg *types.Package, info *types.Info, files []*ast.File) []*Diagnostic {
+	c := &checker{fset: fset, pkg: pkg, info: info}
+
+	for _, file := range files {
+		c.checkFormats(file)
+		c.checkSkips(file)
+
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				c.checkUnwrappedReturns(fd)
+			}
+		}
+	}
+
+	return c.diags
+}
+
+func (c *checker) report(pos token.Pos, format string, a ...interface{}) {
+	c.diags = append(c.diags, &Diagnostic{
+		Pos:     c.fset.Position(pos),
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// checkFormats reports calls to errorz formatting functions whose number of format verbs doesn't match the number of
+// arguments passed using errorz.A (or errorz.Args).
+func (c *checker) checkFormats(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		name := c.errorzFuncName(call)
+		formatIndex, ok := formatArgIndexes[name]
+		if !ok || len(call.Args) <= formatIndex || call.Ellipsis.IsValid() {
+			return true
+		}
+
+		tv, ok := c.info.Types[call.Args[formatIndex]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return true
+		}
+
+		verbs, ok := countVerbs(constant.StringVal(tv.Value))
+		if !ok {
+			return true
+		}
+
+		args, ok := c.countArgs(call.Args[formatIndex+1:])
+		if !ok {
+			return true
+		}
+
+		if verbs != args {
+			c.report(call.Pos(), "errorz.%v format has %v verb(s) but %v arg(s): format arguments must be passed using errorz.A(...)",
+				name, verbs, args)
+		}
+
+		return true
+	})
+}
+
+// countArgs counts the format arguments in the given options, returning false if the number can't be determined.
+func (c *checker) countArgs(options []ast.Expr) (int, bool) {
+	n := 0
+
+	for _, option := range options {
+		switch option := unparen(option).(type) {
+		case *ast.CallExpr:
+			if c.errorzFuncName(option) == "A" {
+				if option.Ellipsis.IsValid() {
+					return 0, false
+				}
+				n += len(option.Args)
+				continue
+			}
+		case *ast.CompositeLit:
+			if c.isErrorzType(c.info.TypeOf(option), "Args") {
+				n += len(option.Elts)
+				continue
+			}
+		}
+
+		if c.isErrorzType(c.info.TypeOf(option), "Args") {
+			return 0, false
+		}
+	}
+
+	return n, true
+}
+
+// checkSkips reports calls to errorz.Skip (and variants) made from functions which don't wrap errors, since they
+// capture the frame they are called from.
+func (c *checker) checkSkips(file *ast.File) {
+	var visit func(n ast.Node, wraps bool) bool
+
+	visit = func(n ast.Node, wraps bool) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				inner := c.callsWrappingFunc(n.Body)
+				ast.Inspect(n.Body, func(m ast.Node) bool { return visit(m, inner) })
+			}
+			return false
+		case *ast.FuncLit:
+			inner := c.callsWrappingFunc(n.Body)
+			ast.Inspect(n.Body, func(m ast.Node) bool { return visit(m, inner) })
+			return false
+		case *ast.CallExpr:
+			if name := c.errorzFuncName(n); !wraps && isSkipFunc(name) {
+				c.report(n.Pos(), "errorz.%v called from a function that doesn't wrap errors: it captures this frame, not the caller's", name)
+			}
+		}
+		return true
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool { return visit(n, false) })
+}
+
+// callsWrappingFunc returns true if the body calls an errorz wrapping function, or a function accepting variadic
+// errorz options (which presumably forwards them to one), excluding nested function literals.
+func (c *checker) callsWrappingFunc(body *ast.BlockStmt) bool {
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if _, ok := wrappingFuncs[c.errorzFuncName(n)]; ok || c.acceptsOptions(n) {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+// acceptsOptions returns true if the called function has a variadic errorz.Option parameter.
+func (c *checker) acceptsOptions(call *ast.CallExpr) bool {
+	if fn := c.callee(call); fn != nil {
+		if sig, ok := fn.Type().(*types.Signature); ok && sig.Variadic() {
+			last := sig.Params().At(sig.Params().Len() - 1).Type()
+			if slice, ok := last.(*types.Slice); ok {
+				return c.isErrorzType(slice.Elem(), "Option")
+			}
+		}
+	}
+	return false
+}
+
+// checkUnwrappedReturns reports exported functions returning errors obtained from other packages without wrapping.
+func (c *checker) checkUnwrappedReturns(fd *ast.FuncDecl) {
+	if fd.Body == nil || !fd.Name.IsExported() || !c.returnsError(fd) {
+		return
+	}
+
+	// origins tracks, for each variable, the external function whose error was last assigned to it.
+	origins := map[types.Object]string{}
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			c.trackAssign(origins, n)
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 {
+				return true
+			}
+			last := unparen(n.Results[len(n.Results)-1])
+
+			if call, ok := last.(*ast.CallExpr); ok && len(n.Results) == 1 {
+				if origin := c.externalCallee(call); origin != "" && c.isErrorType(c.info.TypeOf(call)) {
+					c.report(n.Pos(), "exported function %v returns unwrapped error from %v: wrap it using errorz.Wrap", fd.Name.Name, origin)
+				}
+				return true
+			}
+
+			if id, ok := last.(*ast.Ident); ok {
+				if origin := origins[c.info.Uses[id]]; origin != "" {
+					c.report(n.Pos(), "exported function %v returns unwrapped error from %v: wrap it using errorz.Wrap", fd.Name.Name, origin)
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (c *checker) trackAssign(origins map[types.Object]string, n *ast.AssignStmt) {
+	origin := ""
+	errorIndex := -1
+
+	if len(n.Rhs) == 1 {
+		if call, ok := unparen(n.Rhs[0]).(*ast.CallExpr); ok {
+			origin = c.externalCallee(call)
+			if tuple, ok := c.info.TypeOf(call).(*types.Tuple); ok && tuple.Len() == len(n.Lhs) {
+				for i := 0; i < tuple.Len(); i++ {
+					if c.isErrorType(tuple.At(i).Type()) {
+						errorIndex = i
+					}
+				}
+			} else if len(n.Lhs) == 1 && c.isErrorType(c.info.TypeOf(call)) {
+				errorIndex = 0
+			}
+		}
+	}
+
+	for i, lhs := range n.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		obj := c.info.Defs[id]
+		if obj == nil {
+			obj = c.info.Uses[id]
+		}
+		if obj == nil {
+			continue
+		}
+
+		if i == errorIndex {
+			origins[obj] = origin
+		} else if c.isErrorType(obj.Type()) {
+			origins[obj] = ""
+		}
+	}
+}
+
+// externalCallee returns the qualified name of the called function if it belongs to another package (excluding
+// errorz and the error constructors), an empty string otherwise.
+func (c *checker) externalCallee(call *ast.CallExpr) string {
+	fn := c.callee(call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg() == c.pkg || fn.Pkg().Path() == errorzPkgPath {
+		return ""
+	}
+
+	if _, ok := errorConstructors[fn.Pkg().Path()+"."+fn.Name()]; ok {
+		return ""
+	}
+
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		return fn.Pkg().Name() + "." + recvName(sig.Recv().Type()) + "." + fn.Name()
+	}
+
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+func (c *checker) returnsError(fd *ast.FuncDecl) bool {
+	obj, ok := c.info.Defs[fd.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	results := obj.Type().(*types.Signature).Results()
+	return results.Len() > 0 && c.isErrorType(results.At(results.Len()-1).Type())
+}
+
+func (c *checker) callee(call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+
+	switch fun := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := c.info.Uses[id].(*types.Func)
+	return fn
+}
+
+func (c *checker) errorzFuncName(call *ast.CallExpr) string {
+	if fn := c.callee(call); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == errorzPkgPath {
+		if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() == nil {
+			return fn.Name()
+		}
+	}
+	return ""
+}
+
+func (c *checker) isErrorzType(t types.Type, name string) bool {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		return obj.Pkg() != nil && obj.Pkg().Path() == errorzPkgPath && obj.Name() == name
+	}
+	return false
+}
+
+func (c *checker) isErrorType(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func isSkipFunc(name string) bool {
+	_, ok := skipFuncs[name]
+	return ok
+}
+
+func recvName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		return "(*" + recvName(p.Elem()) + ")"
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return t.String()
+}
+
+// countVerbs counts the arguments consumed by a format string, returning false if explicit argument indexes are used.
+func countVerbs(format string) (int, bool) {
+	n := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+
+		i, n = skipNumber(format, i, n)
+
+		if i < len(format) && format[i] == '.' {
+			i, n = skipNumber(format, i+1, n)
+		}
+
+		if i >= len(format) {
+			break
+		}
+
+		switch format[i] {
+		case '%':
+			continue
+		case '[':
+			return 0, false
+		default:
+			n++
+		}
+	}
+
+	return n, true
+}
+
+func skipNumber(format string, i, n int) (int, int) {
+	if i < len(format) && format[i] == '*' {
+		return i + 1, n + 1
+	}
+	for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+		i++
+	}
+	return i, n
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
@@ -0,0 +1,78 @@
+// Command errorzvet reports common misuses of the errorz package:
+//   - calls to errorz.Errorf (and variants) whose format verbs don't match the arguments passed using errorz.A;
+//   - calls to errorz.Skip (and variants) from functions which don't wrap errors;
+//   - exported functions returning errors from other packages without wrapping them.
+//
+// Usage:
+//
+//	errorzvet [-tests] [packages]
+//
+// Packages are given as directories, with the "/..." suffix for recursive matching (defaults to "./...").
+// Diagnostics are printed in "file:line:col: message" form, and the exit status is 1 if any is reported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ibrt/golang-errors/internal/loader"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("errorzvet", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tests := flags.Bool("tests", false, "also check test files")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	dirs, err := loader.ExpandPatterns(patterns)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	l := loader.New(*tests)
+	var diags []*Diagnostic
+
+	for _, dir := range dirs {
+		pkgs, err := l.LoadDir(dir)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		for _, pkg := range pkgs {
+			diags = append(diags, check(l.Fset, pkg.Types, pkg.Info, pkg.Files)...)
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Pos.Filename != diags[j].Pos.Filename {
+			return diags[i].Pos.Filename < diags[j].Pos.Filename
+		}
+		return diags[i].Pos.Offset < diags[j].Pos.Offset
+	})
+
+	for _, diag := range diags {
+		fmt.Fprintln(stdout, diag)
+	}
+
+	if len(diags) > 0 {
+		return 1
+	}
+	return 0
+}
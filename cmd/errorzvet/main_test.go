@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	require.Equal(t, 1, run([]string{"./testdata/a"}, stdout, stderr))
+	require.Empty(t, stderr.String())
+
+	file := filepath.Join("testdata", "a", "a.go")
+	require.Equal(t, []string{
+		file + ":12:12: errorz.Skip called from a function that doesn't wrap errors: it captures this frame, not the caller's",
+		file + ":19:6: errorz.Errorf format has 1 verb(s) but 0 arg(s): format arguments must be passed using errorz.A(...)",
+		file + ":20:6: errorz.Errorf format has 0 verb(s) but 1 arg(s): format arguments must be passed using errorz.A(...)",
+		file + ":21:2: errorz.Assertf format has 2 verb(s) but 1 arg(s): format arguments must be passed using errorz.A(...)",
+		file + ":28:25: errorz.Skip called from a function that doesn't wrap errors: it captures this frame, not the caller's",
+		file + ":37:10: errorz.SkipAll called from a function that doesn't wrap errors: it captures this frame, not the caller's",
+		file + ":43:2: exported function Direct returns unwrapped error from os.Remove: wrap it using errorz.Wrap",
+		file + ":49:3: exported function Assigned returns unwrapped error from strconv.Atoi: wrap it using errorz.Wrap",
+		file + ":67:2: exported function Method returns unwrapped error from os.(*File).Close: wrap it using errorz.Wrap",
+	}, strings.Split(strings.TrimSpace(stdout.String()), "\n"))
+}
+
+func TestRunClean(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	require.Equal(t, 0, run([]string{"-tests", "../../..."}, stdout, stderr))
+	require.Empty(t, stdout.String())
+	require.Empty(t, stderr.String())
+}
+
+func TestRunErrors(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	require.Equal(t, 2, run([]string{"-unknown"}, &bytes.Buffer{}, stderr))
+	require.Contains(t, stderr.String(), "flag provided but not defined")
+
+	stderr.Reset()
+	require.Equal(t, 2, run([]string{"./does-not-exist"}, &bytes.Buffer{}, stderr))
+	require.NotEmpty(t, stderr.String())
+
+	stderr.Reset()
+	require.Equal(t, 2, run([]string{"./does-not-exist/..."}, &bytes.Buffer{}, stderr))
+	require.NotEmpty(t, stderr.String())
+}
+
+func TestCountVerbs(t *testing.T) {
+	for format, expected := range map[string]int{
+		"":           0,
+		"plain":      0,
+		"%v":         1,
+		"%v %d %s":   3,
+		"100%%":      0,
+		"%+v %#v":    2,
+		"%5.2f":      1,
+		"%*d":        2,
+		"%.*f":       2,
+		"%-*.*f":     3,
+		"trailing %": 0,
+	} {
+		n, ok := countVerbs(format)
+		require.True(t, ok, format)
+		require.Equal(t, expected, n, format)
+	}
+
+	_, ok := countVerbs("%[1]v")
+	require.False(t, ok)
+}
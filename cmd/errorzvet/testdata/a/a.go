@@ -0,0 +1,90 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var skip = errorz.Skip() // want: Skip outside wrapping function
+
+func Formats(id string) error {
+	_ = errorz.Errorf("ok %v %d", errorz.A(1, 2), errorz.ID("x"))
+	_ = errorz.Errorf("ok %v %v", errorz.A(1), errorz.Args{2})
+	_ = errorz.Errorf("ok 100%% %*d", errorz.A(3, 4))
+	_ = errorz.Errorf("ok %[1]v", errorz.A(1, 2))
+	_ = errorz.Errorf("user %v", errorz.ID(id))      // want: 1 verb, 0 args
+	_ = errorz.Errorf("user", errorz.A(id))          // want: 0 verbs, 1 arg
+	errorz.Assertf(true, "value %v %v", errorz.A(1)) // want: 2 verbs, 1 arg
+	args := errorz.A(1)
+	_ = errorz.Errorf("unknown %v %v", args)
+	return errorz.Errorf("ok")
+}
+
+func options() []errorz.Option {
+	return []errorz.Option{errorz.Skip()} // want: Skip outside wrapping function
+}
+
+func wrap(err error) error {
+	return errorz.Wrap(err, errorz.Skip(), errorz.SkipPackage())
+}
+
+func Closure() error {
+	f := func() errorz.Option {
+		return errorz.SkipAll() // want: Skip outside wrapping function
+	}
+	return errorz.Errorf("closure", f())
+}
+
+func Direct() error {
+	return os.Remove("x") // want: unwrapped
+}
+
+func Assigned() (int, error) {
+	n, err := strconv.Atoi("x")
+	if err != nil {
+		return 0, err // want: unwrapped
+	}
+	err = errorz.Wrap(err)
+	return n, err
+}
+
+func Wrapped() error {
+	if err := os.Remove("x"); err != nil {
+		return errorz.Wrap(err)
+	}
+	return nil
+}
+
+func Method() error {
+	f, err := os.Open("x")
+	if err != nil {
+		return errorz.Wrap(err)
+	}
+	return f.Close() // want: unwrapped
+}
+
+func unexported() error {
+	return os.Remove("x")
+}
+
+func Forward(err error) error {
+	return forward(err, errorz.Skip())
+}
+
+func forward(err error, options ...errorz.Option) error {
+	return errorz.Wrap(err, append(options, errorz.Skip())...)
+}
+
+func Constructed(err error) error {
+	if err != nil {
+		return fmt.Errorf("constructed: %w", err)
+	}
+	if err = errors.Join(err, err); err != nil {
+		return err
+	}
+	return errors.New("constructed")
+}
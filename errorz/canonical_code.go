@@ -0,0 +1,181 @@
+package errorz
+
+import (
+	"net/http"
+)
+
+var (
+	_ Option = CanonicalCode(0)
+)
+
+// CanonicalCode describes a canonical error code. Values and string forms mirror the canonical gRPC codes, so that
+// adapters can convert them without errorz depending on gRPC.
+type CanonicalCode int
+
+// Known canonical codes.
+const (
+	CanonicalCodeOK CanonicalCode = iota
+	CanonicalCodeCanceled
+	CanonicalCodeUnknown
+	CanonicalCodeInvalidArgument
+	CanonicalCodeDeadlineExceeded
+	CanonicalCodeNotFound
+	CanonicalCodeAlreadyExists
+	CanonicalCodePermissionDenied
+	CanonicalCodeResourceExhausted
+	CanonicalCodeFailedPrecondition
+	CanonicalCodeAborted
+	CanonicalCodeOutOfRange
+	CanonicalCodeUnimplemented
+	CanonicalCodeInternal
+	CanonicalCodeUnavailable
+	CanonicalCodeDataLoss
+	CanonicalCodeUnauthenticated
+)
+
+var (
+	canonicalCodeNames = []string{
+		"OK",
+		"CANCELLED",
+		"UNKNOWN",
+		"INVALID_ARGUMENT",
+		"DEADLINE_EXCEEDED",
+		"NOT_FOUND",
+		"ALREADY_EXISTS",
+		"PERMISSION_DENIED",
+		"RESOURCE_EXHAUSTED",
+		"FAILED_PRECONDITION",
+		"ABORTED",
+		"OUT_OF_RANGE",
+		"UNIMPLEMENTED",
+		"INTERNAL",
+		"UNAVAILABLE",
+		"DATA_LOSS",
+		"UNAUTHENTICATED",
+	}
+
+	canonicalCodeStatuses = []Status{
+		http.StatusOK,
+		499,
+		http.StatusInternalServerError,
+		http.StatusBadRequest,
+		http.StatusGatewayTimeout,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusForbidden,
+		http.StatusTooManyRequests,
+		http.StatusBadRequest,
+		http.StatusConflict,
+		http.StatusBadRequest,
+		http.StatusNotImplemented,
+		http.StatusInternalServerError,
+		http.StatusServiceUnavailable,
+		http.StatusInternalServerError,
+		http.StatusUnauthorized,
+	}
+
+	statusCanonicalCodes = map[Status]CanonicalCode{
+		http.StatusBadRequest:          CanonicalCodeInvalidArgument,
+		http.StatusUnauthorized:        CanonicalCodeUnauthenticated,
+		http.StatusForbidden:           CanonicalCodePermissionDenied,
+		http.StatusNotFound:            CanonicalCodeNotFound,
+		http.StatusConflict:            CanonicalCodeAlreadyExists,
+		http.StatusPreconditionFailed:  CanonicalCodeFailedPrecondition,
+		http.StatusTooManyRequests:     CanonicalCodeResourceExhausted,
+		499:                            CanonicalCodeCanceled,
+		http.StatusInternalServerError: CanonicalCodeInternal,
+		http.StatusNotImplemented:      CanonicalCodeUnimplemented,
+		http.StatusBadGateway:          CanonicalCodeUnavailable,
+		http.StatusServiceUnavailable:  CanonicalCodeUnavailable,
+		http.StatusGatewayTimeout:      CanonicalCodeDeadlineExceeded,
+	}
+)
+
+// String implements the fmt.Stringer interface, returning the canonical name (e.g. "NOT_FOUND").
+func (c CanonicalCode) String() string {
+	if c >= 0 && int(c) < len(canonicalCodeNames) {
+		return canonicalCodeNames[c]
+	}
+	return "UNKNOWN"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c CanonicalCode) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *CanonicalCode) UnmarshalText(buf []byte) error {
+	parsed, ok := ParseCanonicalCode(string(buf))
+	if !ok {
+		return Errorf("unknown canonical code: %q", A(string(buf)), Skip())
+	}
+	*c = parsed
+	return nil
+}
+
+// HTTPStatus returns the HTTP status corresponding to the canonical code.
+func (c CanonicalCode) HTTPStatus() Status {
+	if c >= 0 && int(c) < len(canonicalCodeStatuses) {
+		return canonicalCodeStatuses[c]
+	}
+	return http.StatusInternalServerError
+}
+
+// Apply implements the Option interface.
+func (c CanonicalCode) Apply(err error) {
+	if e, ok := err.(*wrappedError); ok {
+		e.canonicalCode = &c
+	}
+}
+
+// ParseCanonicalCode parses a canonical code from its canonical name (e.g. "NOT_FOUND").
+// The "CANCELED" spelling is accepted as well.
+func ParseCanonicalCode(s string) (CanonicalCode, bool) {
+	if s == "CANCELED" {
+		return CanonicalCodeCanceled, true
+	}
+	for i, name := range canonicalCodeNames {
+		if name == s {
+			return CanonicalCode(i), true
+		}
+	}
+	return CanonicalCodeUnknown, false
+}
+
+// CanonicalCodeFromHTTPStatus returns the canonical code corresponding to the given HTTP status.
+// Unmapped 2xx statuses map to OK, 4xx to FAILED_PRECONDITION, 5xx to INTERNAL, and anything else to UNKNOWN.
+func CanonicalCodeFromHTTPStatus(status Status) CanonicalCode {
+	if c, ok := statusCanonicalCodes[status]; ok {
+		return c
+	}
+
+	switch {
+	case status >= 200 && status < 300:
+		return CanonicalCodeOK
+	case status >= 400 && status < 500:
+		return CanonicalCodeFailedPrecondition
+	case status >= 500 && status < 600:
+		return CanonicalCodeInternal
+	default:
+		return CanonicalCodeUnknown
+	}
+}
+
+// GetCanonicalCode gets the canonical code from the error, falling back to mapping it from its status.
+// Returns OK for nil errors, and UNKNOWN for errors without canonical code or status.
+func GetCanonicalCode(err error) CanonicalCode {
+	if err == nil {
+		return CanonicalCodeOK
+	}
+
+	if e, ok := err.(*wrappedError); ok && e.canonicalCode != nil {
+		return *e.canonicalCode
+	}
+
+	if status := GetStatus(err); status != 0 {
+		return CanonicalCodeFromHTTPStatus(status)
+	}
+
+	return CanonicalCodeUnknown
+}
@@ -0,0 +1,80 @@
+package errorz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestCanonicalCodeStrings(t *testing.T) {
+	require.Equal(t, "OK", errorz.CanonicalCodeOK.String())
+	require.Equal(t, "CANCELLED", errorz.CanonicalCodeCanceled.String())
+	require.Equal(t, "NOT_FOUND", errorz.CanonicalCodeNotFound.String())
+	require.Equal(t, "UNAUTHENTICATED", errorz.CanonicalCodeUnauthenticated.String())
+	require.Equal(t, "UNKNOWN", errorz.CanonicalCode(100).String())
+	require.Equal(t, "UNKNOWN", errorz.CanonicalCode(-1).String())
+
+	for c := errorz.CanonicalCodeOK; c <= errorz.CanonicalCodeUnauthenticated; c++ {
+		parsed, ok := errorz.ParseCanonicalCode(c.String())
+		require.True(t, ok)
+		require.Equal(t, c, parsed)
+	}
+
+	parsed, ok := errorz.ParseCanonicalCode("CANCELED")
+	require.True(t, ok)
+	require.Equal(t, errorz.CanonicalCodeCanceled, parsed)
+	parsed, ok = errorz.ParseCanonicalCode("NotFound")
+	require.False(t, ok)
+	require.Equal(t, errorz.CanonicalCodeUnknown, parsed)
+
+	buf, err := json.Marshal(errorz.CanonicalCodeDeadlineExceeded)
+	require.NoError(t, err)
+	require.Equal(t, `"DEADLINE_EXCEEDED"`, string(buf))
+	var c errorz.CanonicalCode
+	require.NoError(t, json.Unmarshal([]byte(`"UNAVAILABLE"`), &c))
+	require.Equal(t, errorz.CanonicalCodeUnavailable, c)
+	require.EqualError(t, json.Unmarshal([]byte(`"BAD"`), &c), `unknown canonical code: "BAD"`)
+}
+
+func TestCanonicalCodeHTTPStatus(t *testing.T) {
+	require.Equal(t, errorz.Status(http.StatusOK), errorz.CanonicalCodeOK.HTTPStatus())
+	require.Equal(t, errorz.Status(499), errorz.CanonicalCodeCanceled.HTTPStatus())
+	require.Equal(t, errorz.Status(http.StatusNotFound), errorz.CanonicalCodeNotFound.HTTPStatus())
+	require.Equal(t, errorz.Status(http.StatusGatewayTimeout), errorz.CanonicalCodeDeadlineExceeded.HTTPStatus())
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.CanonicalCodeUnavailable.HTTPStatus())
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.CanonicalCode(100).HTTPStatus())
+
+	require.Equal(t, errorz.CanonicalCodeOK, errorz.CanonicalCodeFromHTTPStatus(http.StatusNoContent))
+	require.Equal(t, errorz.CanonicalCodeNotFound, errorz.CanonicalCodeFromHTTPStatus(http.StatusNotFound))
+	require.Equal(t, errorz.CanonicalCodeUnauthenticated, errorz.CanonicalCodeFromHTTPStatus(http.StatusUnauthorized))
+	require.Equal(t, errorz.CanonicalCodeFailedPrecondition, errorz.CanonicalCodeFromHTTPStatus(http.StatusTeapot))
+	require.Equal(t, errorz.CanonicalCodeInternal, errorz.CanonicalCodeFromHTTPStatus(http.StatusHTTPVersionNotSupported))
+	require.Equal(t, errorz.CanonicalCodeUnavailable, errorz.CanonicalCodeFromHTTPStatus(http.StatusBadGateway))
+	require.Equal(t, errorz.CanonicalCodeUnknown, errorz.CanonicalCodeFromHTTPStatus(0))
+	require.Equal(t, errorz.CanonicalCodeUnknown, errorz.CanonicalCodeFromHTTPStatus(http.StatusFound))
+
+	for c := errorz.CanonicalCodeOK; c <= errorz.CanonicalCodeUnauthenticated; c++ {
+		require.NotEqual(t, errorz.CanonicalCodeUnknown, errorz.CanonicalCodeFromHTTPStatus(c.HTTPStatus()), c.String())
+	}
+}
+
+func TestGetCanonicalCode(t *testing.T) {
+	require.Equal(t, errorz.CanonicalCodeOK, errorz.GetCanonicalCode(nil))
+	require.Equal(t, errorz.CanonicalCodeUnknown, errorz.GetCanonicalCode(fmt.Errorf("test error")))
+	require.Equal(t, errorz.CanonicalCodeUnknown, errorz.GetCanonicalCode(errorz.Errorf("test error")))
+	require.Equal(t, errorz.CanonicalCodeNotFound, errorz.GetCanonicalCode(errorz.Errorf("test error", errorz.Status(http.StatusNotFound))))
+	require.Equal(t, errorz.CanonicalCodeOK, errorz.GetCanonicalCode(errorz.Errorf("test error", errorz.CanonicalCodeOK)))
+
+	err := errorz.Errorf("test error", errorz.CanonicalCodeUnavailable)
+	require.Equal(t, errorz.CanonicalCodeUnavailable, errorz.GetCanonicalCode(err))
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetStatus(err))
+
+	err = errorz.Errorf("test error", errorz.CanonicalCodeAborted, errorz.Status(http.StatusTeapot))
+	require.Equal(t, errorz.CanonicalCodeAborted, errorz.GetCanonicalCode(err))
+	require.Equal(t, errorz.Status(http.StatusTeapot), errorz.GetStatus(err))
+}
@@ -0,0 +1,167 @@
+package errorz
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"sync"
+)
+
+var (
+	classifiersM = &sync.RWMutex{}
+	classifiers  = []*Classifier{}
+)
+
+// Classification describes the id, status and retryability inferred for an error.
+// Zero-valued fields (or a nil Retryable) are not inferred.
+type Classification struct {
+	ID        ID
+	Status    Status
+	Retryable *bool
+}
+
+// Classifier classifies an error, returning false if it doesn't recognize it.
+// Classifiers are called on each error of the chain individually, so they should not unwrap errors.
+type Classifier func(err error) (Classification, bool)
+
+// RegisterClassifier registers a classifier, returning a function which unregisters it.
+// Registered classifiers are tried in reverse registration order, before the built-in ones.
+func RegisterClassifier(c Classifier) func() {
+	classifiersM.Lock()
+	defer classifiersM.Unlock()
+
+	p := &c
+	classifiers = append(classifiers, p)
+
+	return func() {
+		classifiersM.Lock()
+		defer classifiersM.Unlock()
+
+		for i, other := range classifiers {
+			if other == p {
+				classifiers = append(classifiers[:i:i], classifiers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// GetClassification classifies the error, trying the registered and built-in classifiers on each error of the
+// chain, starting from the outermost one. The first match wins. On each error, registered classifiers are tried before
+// the built-in ones, so that they can override them (the same precedence as extractors, see ExtractMetadata).
+// Classifiers are called without holding any lock, so they can use the rest of the package.
+//
+// The built-in classifiers recognize:
+//   - fs.ErrNotExist and sql.ErrNoRows ("not-found", 404);
+//   - fs.ErrExist ("already-exists", 409);
+//   - fs.ErrPermission ("permission-denied", 403);
+//   - context.DeadlineExceeded and timeout errors ("deadline-exceeded", 504, retryable);
+//   - context.Canceled ("canceled", 499);
+//   - *net.OpError, sql.ErrConnDone and driver.ErrBadConn ("unavailable", 503, retryable);
+//   - io.ErrUnexpectedEOF ("unexpected-eof", no status: it can be caused by either a truncated request or a failed
+//     upstream connection).
+//
+// The returned Classification is never shared, so its Retryable pointer can be modified.
+func GetClassification(err error) (Classification, bool) {
+	classifiersM.RLock()
+	registered := classifiers
+	classifiersM.RUnlock()
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		for i := len(registered) - 1; i >= 0; i-- {
+			if c, ok := (*registered[i])(err); ok {
+				return c, true
+			}
+		}
+
+		if c, ok := classifyBuiltIn(err); ok {
+			return c, true
+		}
+	}
+
+	return Classification{}, false
+}
+
+// Classify sets id, status and retryability on the error from its classification (see GetClassification), unless
+// already set. Note that the getters already fall back to the classification: this option makes it explicit.
+func Classify() OptionFunc {
+	return func(err error) {
+		if e, ok := err.(*wrappedError); ok {
+			c, ok := GetClassification(e.err)
+			if !ok {
+				return
+			}
+			if e.id == "" {
+				e.id = c.ID
+			}
+			if e.status == 0 {
+				e.status = c.Status
+			}
+			if e.retryable == nil && c.Retryable != nil {
+				e.retryable = boolPtr(*c.Retryable)
+			}
+		}
+	}
+}
+
+func classifyBuiltIn(err error) (Classification, bool) {
+	switch {
+	case isExactly(err, fs.ErrNotExist), isExactly(err, sql.ErrNoRows):
+		return newClassification("not-found", http.StatusNotFound, false), true
+	case isExactly(err, fs.ErrExist):
+		return newClassification("already-exists", http.StatusConflict, false), true
+	case isExactly(err, fs.ErrPermission):
+		return newClassification("permission-denied", http.StatusForbidden, false), true
+	case isExactly(err, context.DeadlineExceeded):
+		return newDeadlineExceededClassification(), true
+	case isExactly(err, context.Canceled):
+		return newClassification("canceled", 499, false), true
+	case isExactly(err, sql.ErrConnDone), isExactly(err, driver.ErrBadConn):
+		return newUnavailableClassification(), true
+	case isExactly(err, io.ErrUnexpectedEOF):
+		return newClassification("unexpected-eof", 0, false), true
+	}
+
+	if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+		return newDeadlineExceededClassification(), true
+	}
+
+	if _, ok := err.(*net.OpError); ok {
+		return newUnavailableClassification(), true
+	}
+
+	return Classification{}, false
+}
+
+// newClassification returns a built-in classification, with its own Retryable pointer.
+func newClassification(id ID, status Status, retryable bool) Classification {
+	return Classification{ID: id, Status: status, Retryable: boolPtr(retryable)}
+}
+
+func newDeadlineExceededClassification() Classification {
+	return newClassification("deadline-exceeded", http.StatusGatewayTimeout, true)
+}
+
+func newUnavailableClassification() Classification {
+	return newClassification("unavailable", http.StatusServiceUnavailable, true)
+}
+
+// isExactly is like errors.Is, but it does not unwrap err.
+func isExactly(err, target error) bool {
+	if err == target {
+		return true
+	}
+	if x, ok := err.(interface{ Is(error) bool }); ok {
+		return x.Is(target)
+	}
+	return false
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,146 @@
+package errorz_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type testClassifiedError struct{}
+
+// Error implements the error interface.
+func (*testClassifiedError) Error() string {
+	return "classified error"
+}
+
+func TestGetClassification(t *testing.T) {
+	_, ok := errorz.GetClassification(nil)
+	require.False(t, ok)
+	_, ok = errorz.GetClassification(fmt.Errorf("test error"))
+	require.False(t, ok)
+
+	_, err := os.Open("/does/not/exist")
+	c, ok := errorz.GetClassification(err)
+	require.True(t, ok)
+	require.Equal(t, errorz.ID("not-found"), c.ID)
+	require.Equal(t, errorz.Status(http.StatusNotFound), c.Status)
+	require.False(t, *c.Retryable)
+
+	*c.Retryable = true
+	c, ok = errorz.GetClassification(err)
+	require.True(t, ok)
+	require.False(t, *c.Retryable)
+
+	for _, tc := range []struct {
+		err    error
+		id     errorz.ID
+		status errorz.Status
+	}{
+		{sql.ErrNoRows, "not-found", http.StatusNotFound},
+		{os.ErrExist, "already-exists", http.StatusConflict},
+		{os.ErrPermission, "permission-denied", http.StatusForbidden},
+		{context.DeadlineExceeded, "deadline-exceeded", http.StatusGatewayTimeout},
+		{os.ErrDeadlineExceeded, "deadline-exceeded", http.StatusGatewayTimeout},
+		{context.Canceled, "canceled", 499},
+		{sql.ErrConnDone, "unavailable", http.StatusServiceUnavailable},
+		{&net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, "unavailable", http.StatusServiceUnavailable},
+		{io.ErrUnexpectedEOF, "unexpected-eof", 0},
+	} {
+		err := errorz.Wrap(fmt.Errorf("wrapped: %w", tc.err))
+		c, ok := errorz.GetClassification(err)
+		require.True(t, ok, tc.err.Error())
+		require.Equal(t, tc.id, c.ID)
+		require.Equal(t, tc.status, c.Status)
+		require.Equal(t, tc.id, errorz.GetID(err))
+		require.Equal(t, tc.status, errorz.GetStatus(err))
+		require.Equal(t, tc.id, errorz.GetID(tc.err))
+	}
+}
+
+func TestGetClassificationPrecedence(t *testing.T) {
+	err := errorz.Wrap(sql.ErrNoRows, errorz.ID("user-not-found"))
+	require.Equal(t, errorz.ID("user-not-found"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusNotFound), errorz.GetStatus(err))
+
+	err = errorz.Wrap(sql.ErrNoRows, errorz.Status(http.StatusBadRequest))
+	require.Equal(t, errorz.ID("not-found"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusBadRequest), errorz.GetStatus(err))
+
+	require.True(t, errorz.IsRetryable(errorz.Wrap(sql.ErrConnDone)))
+	require.False(t, errorz.IsRetryable(errorz.Wrap(sql.ErrConnDone, errorz.Retryable(false))))
+	require.False(t, errorz.IsRetryable(errorz.Wrap(sql.ErrNoRows)))
+}
+
+func TestRegisterClassifier(t *testing.T) {
+	unregister := errorz.RegisterClassifier(func(err error) (errorz.Classification, bool) {
+		if _, ok := err.(*testClassifiedError); ok {
+			return errorz.Classification{ID: "custom", Status: http.StatusTeapot}, true
+		}
+		return errorz.Classification{}, false
+	})
+	unregisterOverride := errorz.RegisterClassifier(func(err error) (errorz.Classification, bool) {
+		if err == sql.ErrNoRows {
+			return errorz.Classification{ID: "no-rows"}, true
+		}
+		return errorz.Classification{}, false
+	})
+
+	err := errorz.Wrap(fmt.Errorf("wrapped: %w", &testClassifiedError{}))
+	require.Equal(t, errorz.ID("custom"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusTeapot), errorz.GetStatus(err))
+	require.False(t, errorz.IsRetryable(err))
+	require.Equal(t, errorz.ID("no-rows"), errorz.GetID(sql.ErrNoRows))
+	require.Equal(t, errorz.Status(0), errorz.GetStatus(sql.ErrNoRows))
+
+	unregisterOverride()
+	unregisterOverride()
+	require.Equal(t, errorz.ID("not-found"), errorz.GetID(sql.ErrNoRows))
+	unregister()
+	require.Equal(t, errorz.ID(""), errorz.GetID(err))
+}
+
+func TestRegisterClassifierReentrant(t *testing.T) {
+	unregister := errorz.RegisterClassifier(func(err error) (errorz.Classification, bool) {
+		if _, ok := err.(*testClassifiedError); ok {
+			errorz.RegisterClassifier(func(error) (errorz.Classification, bool) { return errorz.Classification{}, false })()
+			return errorz.Classification{ID: errorz.GetID(sql.ErrNoRows) + "-custom"}, true
+		}
+		return errorz.Classification{}, false
+	})
+	defer unregister()
+
+	done := make(chan errorz.ID)
+	go func() { done <- errorz.GetID(&testClassifiedError{}) }()
+
+	select {
+	case id := <-done:
+		require.Equal(t, errorz.ID("not-found-custom"), id)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "deadlock")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	err := errorz.Wrap(sql.ErrConnDone, errorz.Classify())
+	require.Equal(t, errorz.ID("unavailable"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetStatus(err))
+	require.True(t, errorz.IsRetryable(err))
+
+	err = errorz.Wrap(sql.ErrConnDone, errorz.ID("db"), errorz.Status(http.StatusInternalServerError), errorz.Retryable(false), errorz.Classify())
+	require.Equal(t, errorz.ID("db"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.GetStatus(err))
+	require.False(t, errorz.IsRetryable(err))
+
+	err = errorz.Errorf("test error", errorz.Classify())
+	require.Equal(t, errorz.ID(""), errorz.GetID(err))
+}
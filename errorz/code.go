@@ -0,0 +1,98 @@
+package errorz
+
+import (
+	"sync"
+)
+
+var (
+	_ Option = Code(0)
+)
+
+var (
+	codesM    = &sync.RWMutex{}
+	codesToID = map[Code]ID{}
+	idsToCode = map[ID]Code{}
+)
+
+// Code describes a stable numeric error code, for protocols which cannot carry string ids.
+type Code int
+
+// Int returns the code as "int".
+func (c Code) Int() int {
+	return int(c)
+}
+
+// Apply implements the Option interface.
+func (c Code) Apply(err error) {
+	if e, ok := err.(*wrappedError); ok {
+		e.code = c
+	}
+}
+
+// GetCode gets the code from the error, falling back to the code registered for its id, or 0 if not set.
+func GetCode(err error) Code {
+	if e, ok := err.(*wrappedError); ok {
+		if e.code != 0 {
+			return e.code
+		}
+		if code, ok := GetCodeForID(e.id); ok {
+			return code
+		}
+	}
+	return 0
+}
+
+// RegisterCode registers a bidirectional mapping between the given code and id.
+// It returns an error if either the code or the id are already mapped to something else.
+func RegisterCode(code Code, id ID) error {
+	if code == 0 || id == "" {
+		return Errorf("invalid code registration: %v <-> %q", A(code, id), Skip())
+	}
+
+	codesM.Lock()
+	defer codesM.Unlock()
+
+	if otherID, ok := codesToID[code]; ok && otherID != id {
+		return Errorf("code %v already registered to id %q", A(code, otherID), M("code", code), M("id", id), Skip())
+	}
+
+	if otherCode, ok := idsToCode[id]; ok && otherCode != code {
+		return Errorf("id %q already registered to code %v", A(id, otherCode), M("code", code), M("id", id), Skip())
+	}
+
+	codesToID[code] = id
+	idsToCode[id] = code
+	return nil
+}
+
+// MustRegisterCode is like RegisterCode but panics in case of error.
+func MustRegisterCode(code Code, id ID) {
+	MaybeMustWrap(RegisterCode(code, id), Skip())
+}
+
+// UnregisterCode removes the mapping for the given code, if any.
+func UnregisterCode(code Code) {
+	codesM.Lock()
+	defer codesM.Unlock()
+
+	if id, ok := codesToID[code]; ok {
+		delete(codesToID, code)
+		delete(idsToCode, id)
+	}
+}
+
+// GetCodeForID returns the code registered for the given id.
+func GetCodeForID(id ID) (Code, bool) {
+	codesM.RLock()
+	defer codesM.RUnlock()
+	code, ok := idsToCode[id]
+	return code, ok
+}
+
+// GetIDForCode returns the id registered for the given code.
+func GetIDForCode(code Code) (ID, bool) {
+	codesM.RLock()
+	defer codesM.RUnlock()
+	id, ok := codesToID[code]
+	return id, ok
+}
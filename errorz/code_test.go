@@ -0,0 +1,51 @@
+package errorz_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestCode(t *testing.T) {
+	require.Equal(t, errorz.Code(0), errorz.GetCode(errorz.Errorf("test error")))
+	require.Equal(t, errorz.Code(0), errorz.GetCode(fmt.Errorf("test error")))
+	err := errorz.Errorf("test error", errorz.Code(42))
+	require.Equal(t, errorz.Code(42), errorz.GetCode(err))
+	require.Equal(t, 42, errorz.Code(42).Int())
+}
+
+func TestRegisterCode(t *testing.T) {
+	require.NoError(t, errorz.RegisterCode(1001, "code-test-a"))
+	defer errorz.UnregisterCode(1001)
+	require.NoError(t, errorz.RegisterCode(1001, "code-test-a"))
+	require.NotPanics(t, func() { errorz.MustRegisterCode(1001, "code-test-a") })
+
+	err := errorz.RegisterCode(1001, "code-test-b")
+	require.EqualError(t, err, `code 1001 already registered to id "code-test-a"`)
+	require.Equal(t, errorz.Metadata{"code": errorz.Code(1001), "id": errorz.ID("code-test-b")}, errorz.GetMetadata(err))
+	require.EqualError(t, errorz.RegisterCode(1002, "code-test-a"), `id "code-test-a" already registered to code 1001`)
+	require.EqualError(t, errorz.RegisterCode(0, "code-test-a"), `invalid code registration: 0 <-> "code-test-a"`)
+	require.EqualError(t, errorz.RegisterCode(1002, ""), `invalid code registration: 1002 <-> ""`)
+	require.PanicsWithError(t, `id "code-test-a" already registered to code 1001`, func() { errorz.MustRegisterCode(1002, "code-test-a") })
+
+	code, ok := errorz.GetCodeForID("code-test-a")
+	require.True(t, ok)
+	require.Equal(t, errorz.Code(1001), code)
+	id, ok := errorz.GetIDForCode(1001)
+	require.True(t, ok)
+	require.Equal(t, errorz.ID("code-test-a"), id)
+
+	require.Equal(t, errorz.Code(1001), errorz.GetCode(errorz.Errorf("test error", errorz.ID("code-test-a"))))
+	require.Equal(t, errorz.ID("code-test-a"), errorz.GetID(errorz.Errorf("test error", errorz.Code(1001))))
+	require.Equal(t, errorz.Code(7), errorz.GetCode(errorz.Errorf("test error", errorz.ID("code-test-a"), errorz.Code(7))))
+
+	errorz.UnregisterCode(1001)
+	errorz.UnregisterCode(1001)
+	_, ok = errorz.GetCodeForID("code-test-a")
+	require.False(t, ok)
+	_, ok = errorz.GetIDForCode(1001)
+	require.False(t, ok)
+}
@@ -0,0 +1,128 @@
+package errorz
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey int
+
+const (
+	optionsContextKey contextKey = iota
+)
+
+// WithOptions returns a copy of the context carrying the given default options, in addition to the ones already
+// carried by it. Default options are applied by WrapCtx and ErrorfCtx, but only fill in what is not already set on the
+// error, either by the explicit options or, for errorz errors, when they were created.
+func WithOptions(ctx context.Context, options ...Option) context.Context {
+	merged := append(append([]Option{}, GetOptions(ctx)...), options...)
+	return context.WithValue(ctx, optionsContextKey, merged)
+}
+
+// GetOptions returns the default options carried by the context, nil if none.
+func GetOptions(ctx context.Context) []Option {
+	if options, ok := ctx.Value(optionsContextKey).([]Option); ok {
+		return options
+	}
+	return nil
+}
+
+// WrapCtx is like Wrap, but it also applies the default options carried by the context (see WithOptions). If the
+// context is done, its error and cause are attached as metadata under the "context-error" and "context-cause" keys.
+// Errorz errors are cloned (see Clone) rather than modified in place, as they may be shared.
+func WrapCtx(ctx context.Context, err error, options ...Option) error {
+	if err == nil {
+		panic("nil error")
+	}
+
+	options = append(options, contextDefaults(ctx), Skip())
+	return Wrap(Clone(err), options...)
+}
+
+// MaybeWrapCtx is like WrapCtx, but returns nil if called with a nil error.
+func MaybeWrapCtx(ctx context.Context, err error, options ...Option) error {
+	if err == nil {
+		return nil
+	}
+
+	options = append(options, contextDefaults(ctx), Skip())
+	return Wrap(Clone(err), options...)
+}
+
+// ErrorfCtx is like Errorf, but it also applies the default options carried by the context (see WrapCtx).
+func ErrorfCtx(ctx context.Context, format string, options ...Option) error {
+	options = append(options, contextDefaults(ctx), Skip())
+	return Errorf(format, options...)
+}
+
+// contextDefaults returns an option which applies the default options carried by the context to a scratch error,
+// then copies over the fields which are not already set on the error. Prefixes are additive, the default ones go
+// innermost. It must be applied after the explicit options.
+func contextDefaults(ctx context.Context) OptionFunc {
+	return func(err error) {
+		if e, ok := err.(*wrappedError); ok {
+			applyContextDefaults(ctx, e)
+		}
+	}
+}
+
+func applyContextDefaults(ctx context.Context, e *wrappedError) {
+	defaults := GetOptions(ctx)
+	ctxErr := ctx.Err()
+
+	if len(defaults) == 0 && ctxErr == nil {
+		return
+	}
+
+	d := &wrappedError{metadata: Metadata{}}
+
+	for _, option := range defaults {
+		option.Apply(d)
+	}
+
+	if ctxErr != nil {
+		d.metadata["context-error"] = ctxErr.Error()
+
+		if cause := context.Cause(ctx); cause != nil && cause != ctxErr {
+			d.metadata["context-cause"] = cause.Error()
+		}
+	}
+
+	if e.id == "" {
+		e.id = d.id
+	}
+	if e.code == 0 {
+		e.code = d.code
+	}
+	if e.canonicalCode == nil {
+		e.canonicalCode = d.canonicalCode
+	}
+	if e.status == 0 {
+		e.status = d.status
+	}
+	if e.severity == 0 {
+		e.severity = d.severity
+	}
+	if e.retryable == nil {
+		e.retryable = d.retryable
+	}
+	for k, values := range d.headers {
+		if e.headers == nil {
+			e.headers = http.Header{}
+		}
+		if _, ok := e.headers[k]; !ok {
+			e.headers[k] = values
+		}
+	}
+	for k, v := range d.metadata {
+		if _, ok := e.metadata[k]; !ok {
+			e.metadata[k] = v
+		}
+	}
+	if len(e.fieldErrors) == 0 {
+		e.fieldErrors = d.fieldErrors
+	}
+	if d.prefix != "" {
+		e.prefix += d.prefix
+	}
+}
@@ -0,0 +1,81 @@
+package errorz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestWithOptions(t *testing.T) {
+	require.Nil(t, errorz.GetOptions(context.Background()))
+
+	ctx := errorz.WithOptions(context.Background(), errorz.M("request-id", "r1"))
+	ctx = errorz.WithOptions(ctx, errorz.M("tenant", "t1"), errorz.Status(http.StatusInternalServerError))
+	require.Len(t, errorz.GetOptions(ctx), 3)
+
+	err := errorz.WrapCtx(ctx, fmt.Errorf("test error"), errorz.M("tenant", "t2"))
+	require.EqualError(t, err, "test error")
+	require.Equal(t, errorz.Metadata{"request-id": "r1", "tenant": "t2"}, errorz.GetMetadata(err))
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.GetStatus(err))
+	require.True(t, strings.HasPrefix(errorz.FormatStackTrace(errorz.GetCallers(err))[0], "errorz_test.TestWithOptions"))
+
+	err = errorz.ErrorfCtx(ctx, "test error: %v", errorz.A("value"), errorz.Status(http.StatusBadRequest))
+	require.EqualError(t, err, "test error: value")
+	require.Equal(t, errorz.Metadata{"request-id": "r1", "tenant": "t1"}, errorz.GetMetadata(err))
+	require.Equal(t, errorz.Status(http.StatusBadRequest), errorz.GetStatus(err))
+	require.True(t, strings.HasPrefix(errorz.FormatStackTrace(errorz.GetCallers(err))[0], "errorz_test.TestWithOptions"))
+
+	err = errorz.MaybeWrapCtx(ctx, fmt.Errorf("test error"))
+	require.Equal(t, errorz.Metadata{"request-id": "r1", "tenant": "t1"}, errorz.GetMetadata(err))
+	require.True(t, strings.HasPrefix(errorz.FormatStackTrace(errorz.GetCallers(err))[0], "errorz_test.TestWithOptions"))
+	require.Nil(t, errorz.MaybeWrapCtx(ctx, nil))
+	require.PanicsWithValue(t, "nil error", func() { _ = errorz.WrapCtx(ctx, nil) })
+}
+
+func TestWrapCtxDefaults(t *testing.T) {
+	ctx := errorz.WithOptions(context.Background(),
+		errorz.ID("default"),
+		errorz.Status(http.StatusInternalServerError),
+		errorz.SeverityCritical,
+		errorz.M("k1", "default"),
+		errorz.M("k2", "default"),
+		errorz.Prefix("default"))
+
+	inner := errorz.Errorf("test error", errorz.ID("user-not-found"), errorz.Status(http.StatusNotFound), errorz.M("k1", "inner"))
+	err := errorz.WrapCtx(ctx, inner, errorz.Prefix("explicit"))
+	require.NotSame(t, inner, err)
+	require.EqualError(t, err, "explicit: default: test error")
+	require.Equal(t, errorz.ID("user-not-found"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusNotFound), errorz.GetStatus(err))
+	require.Equal(t, errorz.SeverityCritical, errorz.GetSeverity(err))
+	require.Equal(t, errorz.Metadata{"k1": "inner", "k2": "default"}, errorz.GetMetadata(err))
+
+	require.EqualError(t, inner, "test error")
+	require.Equal(t, errorz.Metadata{"k1": "inner"}, errorz.GetMetadata(inner))
+	require.Equal(t, errorz.SeverityWarning, errorz.GetSeverity(inner))
+
+	err = errorz.ErrorfCtx(ctx, "test error", errorz.ID("explicit"))
+	require.Equal(t, errorz.ID("explicit"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.GetStatus(err))
+}
+
+func TestWrapCtxDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := errorz.WrapCtx(ctx, fmt.Errorf("test error"))
+	require.Equal(t, errorz.Metadata{"context-error": "context canceled"}, errorz.GetMetadata(err))
+
+	ctx, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(fmt.Errorf("shutting down"))
+	err = errorz.WrapCtx(ctx, fmt.Errorf("test error"))
+	require.Equal(t, errorz.Metadata{"context-error": "context canceled", "context-cause": "shutting down"}, errorz.GetMetadata(err))
+
+	err = errorz.WrapCtx(context.Background(), fmt.Errorf("test error"))
+	require.Equal(t, errorz.Metadata{}, errorz.GetMetadata(err))
+}
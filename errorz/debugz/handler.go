@@ -0,0 +1,159 @@
+package debugz
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/httpz"
+)
+
+var (
+	pageTemplate = template.Must(template.New("page").Parse(pageTemplateText))
+)
+
+const (
+	defaultLimit = 100
+)
+
+// Page describes the content served by the handler returned by NewHandler.
+type Page struct {
+	Query   *Query   `json:"-"`
+	Groups  []*Group `json:"groups"`
+	Entries []*Entry `json:"entries"`
+}
+
+// NewHandler returns an http.Handler serving the content of the store as HTML, or as JSON if the "format=json" query
+// parameter is given or the request accepts "application/json". The results can be filtered using the query
+// parameters:
+//   - "id": an id or namespace (see errorz.ID.HasPrefix);
+//   - "status": an HTTP status;
+//   - "since": an RFC 3339 time or a duration relative to now (e.g. "15m");
+//   - "until": an RFC 3339 time;
+//   - "limit": the maximum number of groups and entries (default 100).
+func NewHandler(store *Store) http.Handler {
+	return httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		q, err := parseQuery(r, store.now())
+		if err != nil {
+			return errorz.Wrap(err)
+		}
+
+		page := &Page{
+			Query:   q,
+			Groups:  store.Groups(q),
+			Entries: store.Entries(q),
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return errorz.MaybeWrap(enc.Encode(page))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		return errorz.MaybeWrap(pageTemplate.Execute(w, page))
+	})
+}
+
+func parseQuery(r *http.Request, now time.Time) (*Query, error) {
+	v := errorz.NewValidation()
+	params := r.URL.Query()
+	q := &Query{
+		ID:    errorz.ID(params.Get("id")),
+		Limit: defaultLimit,
+	}
+
+	if status := params.Get("status"); status != "" {
+		i, err := strconv.Atoi(status)
+		v.Check(err == nil && i > 0, "status", "status", "must be an HTTP status")
+		q.Status = errorz.Status(i)
+	}
+
+	if since := params.Get("since"); since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			q.Since = now.Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+			q.Since = t
+		} else {
+			v.Add("since", "time", "must be an RFC 3339 time or a duration")
+		}
+	}
+
+	if until := params.Get("until"); until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		v.Check(err == nil, "until", "time", "must be an RFC 3339 time")
+		q.Until = t
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		i, err := strconv.Atoi(limit)
+		v.Check(err == nil && i > 0, "limit", "positive", "must be a positive integer")
+		q.Limit = i
+	}
+
+	return q, v.Err()
+}
+
+const pageTemplateText = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Recent errors</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+pre { margin: 0; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>Recent errors</h1>
+<form>
+<input name="id" placeholder="id" value="{{.Query.ID}}">
+<input name="status" placeholder="status" value="{{if .Query.Status}}{{.Query.Status}}{{end}}">
+<input name="since" placeholder="since (e.g. 15m)">
+<input name="limit" placeholder="limit" value="{{.Query.Limit}}">
+<button type="submit">Filter</button>
+<a href="?format=json">JSON</a>
+</form>
+<h2>Groups</h2>
+<table>
+<tr>
+<th>Count</th><th>First seen</th><th>Last seen</th><th>ID</th><th>Status</th><th>Message</th><th>Fingerprint</th>
+</tr>
+{{range .Groups}}<tr>
+<td>{{.Count}}</td>
+<td>{{.FirstSeen.Format "2006-01-02T15:04:05.000Z07:00"}}</td>
+<td>{{.LastSeen.Format "2006-01-02T15:04:05.000Z07:00"}}</td>
+<td><a href="?id={{.Latest.ID}}">{{.Latest.ID}}</a></td>
+<td>{{if .Latest.Status}}{{.Latest.Status}}{{end}}</td>
+<td>{{.Latest.Message}}</td>
+<td><code>{{.Fingerprint}}</code></td>
+</tr>
+{{else}}<tr><td colspan="7">No errors.</td></tr>
+{{end}}</table>
+<h2>Entries</h2>
+<table>
+<tr><th>Time</th><th>ID</th><th>Status</th><th>Message</th><th>Details</th></tr>
+{{range .Entries}}<tr>
+<td>{{.Time.Format "2006-01-02T15:04:05.000Z07:00"}}</td>
+<td>{{.Summary.ID}}</td>
+<td>{{if .Summary.Status}}{{.Summary.Status}}{{end}}</td>
+<td>{{.Summary.Message}}</td>
+<td>{{if or .Summary.Metadata .Summary.StackTrace}}<details><summary>Details</summary>
+{{range $k, $v := .Summary.Metadata}}<div><b>{{$k}}</b>: {{$v}}</div>
+{{end}}<pre>{{range .Summary.StackTrace}}{{.}}
+{{end}}</pre></details>{{end}}</td>
+</tr>
+{{else}}<tr><td colspan="5">No errors.</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
@@ -0,0 +1,83 @@
+package debugz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/debugz"
+)
+
+func serve(h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	s := debugz.NewStore(nil)
+	h := debugz.NewHandler(s)
+
+	w := serve(h, "/debug/errors", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "No errors.")
+
+	for i := 0; i < 3; i++ {
+		s.Add(newError(i))
+	}
+	s.Add(errorz.Errorf("<script>", errorz.ID("other"), errorz.M("k", "v")))
+
+	w = serve(h, "/debug/errors", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "error 2")
+	require.Contains(t, w.Body.String(), "&lt;script&gt;")
+	require.NotContains(t, w.Body.String(), "<script>")
+	require.Contains(t, w.Body.String(), "<b>k</b>: v")
+
+	w = serve(h, "/debug/errors?format=json&id=db&status=503&since=1h&limit=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	page := &debugz.Page{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), page))
+	require.Len(t, page.Groups, 1)
+	require.Equal(t, "error 2", page.Groups[0].Latest.Message)
+	require.Equal(t, int64(3), page.Groups[0].Count)
+	require.Len(t, page.Entries, 2)
+	require.Equal(t, "error 2", page.Entries[0].Summary.Message)
+
+	w = serve(h, "/debug/errors?until=2000-01-01T00:00:00Z", http.Header{"Accept": {"application/json"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	page = &debugz.Page{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), page))
+	require.Empty(t, page.Groups)
+	require.Empty(t, page.Entries)
+}
+
+func TestHandlerInvalidQuery(t *testing.T) {
+	h := debugz.NewHandler(debugz.NewStore(nil))
+
+	for _, target := range []string{
+		"/?status=x",
+		"/?since=yesterday",
+		"/?until=1h",
+		"/?limit=0",
+	} {
+		w := serve(h, target, nil)
+		require.Equal(t, http.StatusBadRequest, w.Code, target)
+
+		s := &errorz.Summary{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), s), target)
+		require.Equal(t, errorz.InvalidArgumentID, s.ID, fmt.Sprint(target, " ", w.Body.String()))
+	}
+}
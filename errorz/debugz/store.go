@@ -0,0 +1,248 @@
+// Package debugz provides an in-memory store of recent errors and an HTTP handler to inspect it, similar to
+// net/http/pprof. For example:
+//
+//	store := debugz.NewStore(nil)
+//	errorz.SetReporter(errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{store}}))
+//	http.Handle("/debug/errors", debugz.NewHandler(store))
+package debugz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ errorz.Sink = &Store{}
+)
+
+// StoreConfig describes the configuration of a Store.
+type StoreConfig struct {
+	// Capacity is the maximum number of errors retained, older ones are evicted first (default 500).
+	Capacity int
+	// MaxGroups is the maximum number of groups retained, the least recently seen are evicted first (default 500).
+	MaxGroups int
+}
+
+// Entry describes an error retained by a Store. Its time is the time the error was reported (see errorz.Summary), or
+// the time the Store received it for summaries without one.
+type Entry struct {
+	Time    time.Time       `json:"time"`
+	Summary *errorz.Summary `json:"summary"`
+}
+
+// Group describes the errors with the same fingerprint (see errorz.Fingerprint) seen by a Store, including the ones
+// already evicted.
+type Group struct {
+	Fingerprint string          `json:"fingerprint"`
+	Count       int64           `json:"count"`
+	FirstSeen   time.Time       `json:"firstSeen"`
+	LastSeen    time.Time       `json:"lastSeen"`
+	Latest      *errorz.Summary `json:"latest"`
+}
+
+// Query filters the entries and groups returned by a Store. Zero-valued fields match everything.
+type Query struct {
+	// ID matches errors with the given id or within the given namespace (see errorz.ID.HasPrefix).
+	ID errorz.ID
+	// Status matches errors with the given status.
+	Status errorz.Status
+	// Since matches errors seen at or after the given time.
+	Since time.Time
+	// Until matches errors seen before the given time.
+	Until time.Time
+	// Limit caps the number of results.
+	Limit int
+}
+
+// Store is a bounded in-memory store of recent errors, grouped by fingerprint. It is safe for concurrent use, and
+// implements errorz.Sink so that it can receive errors from an errorz.Reporter.
+type Store struct {
+	m         sync.RWMutex
+	entries   []*Entry
+	next      int
+	maxGroups int
+	groups    map[string]*Group
+	now       func() time.Time
+}
+
+// NewStore initializes a new Store.
+func NewStore(cfg *StoreConfig) *Store {
+	capacity, maxGroups := 500, 500
+
+	if cfg != nil && cfg.Capacity > 0 {
+		capacity = cfg.Capacity
+	}
+	if cfg != nil && cfg.MaxGroups > 0 {
+		maxGroups = cfg.MaxGroups
+	}
+
+	return &Store{
+		entries:   make([]*Entry, 0, capacity),
+		maxGroups: maxGroups,
+		groups:    map[string]*Group{},
+		now:       time.Now,
+	}
+}
+
+// Add adds an error to the store.
+func (s *Store) Add(err error) {
+	if err != nil {
+		s.AddSummary(errorz.ToSummary(err))
+	}
+}
+
+// AddSummary adds a summarized error to the store.
+func (s *Store) AddSummary(summary *errorz.Summary) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.addSummary(summary)
+}
+
+// Send implements the errorz.Sink interface.
+func (s *Store) Send(_ context.Context, summaries []*errorz.Summary) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for _, summary := range summaries {
+		s.addSummary(summary)
+	}
+
+	return nil
+}
+
+func (s *Store) addSummary(summary *errorz.Summary) {
+	entry := &Entry{
+		Time:    s.now(),
+		Summary: summary,
+	}
+
+	if summary.Time != nil {
+		entry.Time = *summary.Time
+	}
+
+	if len(s.entries) < cap(s.entries) {
+		s.entries = append(s.entries, entry)
+	} else {
+		s.entries[s.next] = entry
+		s.next = (s.next + 1) % len(s.entries)
+	}
+
+	key := getGroupKey(summary)
+
+	g, ok := s.groups[key]
+	if !ok {
+		if len(s.groups) >= s.maxGroups {
+			s.evictGroup()
+		}
+
+		g = &Group{
+			Fingerprint: summary.Fingerprint,
+			FirstSeen:   entry.Time,
+		}
+		s.groups[key] = g
+	}
+
+	g.Count++
+	g.Latest = summary
+
+	// Reported times are not monotonic across reporters and batches.
+	if entry.Time.Before(g.FirstSeen) {
+		g.FirstSeen = entry.Time
+	}
+	if entry.Time.After(g.LastSeen) {
+		g.LastSeen = entry.Time
+	}
+}
+
+func (s *Store) evictGroup() {
+	var oldestKey string
+	var oldest *Group
+
+	for key, g := range s.groups {
+		if oldest == nil || g.LastSeen.Before(oldest.LastSeen) {
+			oldestKey, oldest = key, g
+		}
+	}
+
+	delete(s.groups, oldestKey)
+}
+
+// Entries returns the entries matching the query, most recent first. A nil query matches everything.
+func (s *Store) Entries(q *Query) []*Entry {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	entries := make([]*Entry, 0)
+
+	for i := 0; i < len(s.entries); i++ {
+		entry := s.entries[(s.next+len(s.entries)-1-i)%len(s.entries)]
+
+		if q.matches(entry.Summary, entry.Time) {
+			if entries = append(entries, entry); q != nil && q.Limit > 0 && len(entries) >= q.Limit {
+				break
+			}
+		}
+	}
+
+	return entries
+}
+
+// Groups returns the groups matching the query (by their latest error and last seen time), most recently seen first.
+// A nil query matches everything.
+func (s *Store) Groups(q *Query) []*Group {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	groups := make([]*Group, 0)
+
+	for _, g := range s.groups {
+		if q.matches(g.Latest, g.LastSeen) {
+			gCopy := *g
+			groups = append(groups, &gCopy)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].LastSeen.After(groups[j].LastSeen)
+	})
+
+	if q != nil && q.Limit > 0 && len(groups) > q.Limit {
+		groups = groups[:q.Limit]
+	}
+
+	return groups
+}
+
+// Reset removes all entries and groups from the store.
+func (s *Store) Reset() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.entries = s.entries[:0]
+	s.next = 0
+	s.groups = map[string]*Group{}
+}
+
+func (q *Query) matches(summary *errorz.Summary, t time.Time) bool {
+	if q == nil {
+		return true
+	}
+
+	return (q.ID == "" || summary.ID.HasPrefix(string(q.ID))) &&
+		(q.Status == 0 || summary.Status == q.Status) &&
+		(q.Since.IsZero() || !t.Before(q.Since)) &&
+		(q.Until.IsZero() || t.Before(q.Until))
+}
+
+func getGroupKey(summary *errorz.Summary) string {
+	if summary.Fingerprint != "" {
+		return summary.Fingerprint
+	}
+	return fmt.Sprintf("%v\n%v", summary.ID, summary.Message)
+}
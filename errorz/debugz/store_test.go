@@ -0,0 +1,132 @@
+package debugz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/debugz"
+)
+
+func newError(i int) error {
+	return errorz.Errorf("error %v", errorz.A(i), errorz.ID("db.conn"), errorz.Status(http.StatusServiceUnavailable))
+}
+
+func TestStore(t *testing.T) {
+	s := debugz.NewStore(&debugz.StoreConfig{Capacity: 3})
+	require.Empty(t, s.Entries(nil))
+	require.Empty(t, s.Groups(nil))
+
+	for i := 0; i < 4; i++ {
+		s.Add(newError(i))
+	}
+	s.Add(nil)
+	s.Add(errorz.Errorf("other error", errorz.ID("http"), errorz.Status(http.StatusNotFound)))
+
+	entries := s.Entries(nil)
+	require.Len(t, entries, 3)
+	require.Equal(t, "other error", entries[0].Summary.Message)
+	require.Equal(t, "error 3", entries[1].Summary.Message)
+	require.Equal(t, "error 2", entries[2].Summary.Message)
+
+	groups := s.Groups(nil)
+	require.Len(t, groups, 2)
+	require.Equal(t, "other error", groups[0].Latest.Message)
+	require.Equal(t, int64(1), groups[0].Count)
+	require.Equal(t, "error 3", groups[1].Latest.Message)
+	require.Equal(t, int64(4), groups[1].Count)
+	require.Equal(t, groups[1].Latest.Fingerprint, groups[1].Fingerprint)
+	require.False(t, groups[1].FirstSeen.After(groups[1].LastSeen))
+
+	s.Reset()
+	require.Empty(t, s.Entries(nil))
+	require.Empty(t, s.Groups(nil))
+}
+
+func TestStoreQuery(t *testing.T) {
+	s := debugz.NewStore(nil)
+
+	s.Add(newError(0))
+	time.Sleep(time.Millisecond)
+	since := time.Now()
+	s.Add(newError(1))
+	s.Add(errorz.Errorf("other error", errorz.ID("db"), errorz.Status(http.StatusNotFound)))
+	s.Add(errorz.Errorf("other error", errorz.ID("dbx")))
+
+	require.Len(t, s.Entries(&debugz.Query{ID: "db"}), 3)
+	require.Len(t, s.Entries(&debugz.Query{ID: "db.conn"}), 2)
+	require.Len(t, s.Entries(&debugz.Query{Status: http.StatusNotFound}), 1)
+	require.Len(t, s.Entries(&debugz.Query{Since: since}), 3)
+	require.Len(t, s.Entries(&debugz.Query{Until: since}), 1)
+	require.Len(t, s.Entries(&debugz.Query{Limit: 2}), 2)
+
+	require.Len(t, s.Groups(&debugz.Query{ID: "db"}), 2)
+	require.Len(t, s.Groups(&debugz.Query{Status: http.StatusServiceUnavailable}), 1)
+	require.Len(t, s.Groups(&debugz.Query{Limit: 1}), 1)
+	require.Equal(t, "dbx", string(s.Groups(&debugz.Query{Limit: 1})[0].Latest.ID))
+}
+
+func TestStoreMaxGroups(t *testing.T) {
+	s := debugz.NewStore(&debugz.StoreConfig{MaxGroups: 2})
+
+	for i := 0; i < 3; i++ {
+		s.AddSummary(&errorz.Summary{ID: errorz.ID(fmt.Sprintf("id-%v", i)), Message: "error"})
+		time.Sleep(time.Millisecond)
+	}
+
+	groups := s.Groups(nil)
+	require.Len(t, groups, 2)
+	require.Equal(t, errorz.ID("id-2"), groups[0].Latest.ID)
+	require.Equal(t, errorz.ID("id-1"), groups[1].Latest.ID)
+	require.Len(t, s.Entries(nil), 3)
+}
+
+func TestStoreReportedTime(t *testing.T) {
+	s := debugz.NewStore(nil)
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, offset := range []time.Duration{time.Minute, 0, 2 * time.Minute} {
+		summary := errorz.ToSummary(newError(0))
+		reported := t0.Add(offset)
+		summary.Time = &reported
+		require.NoError(t, s.Send(context.Background(), []*errorz.Summary{summary}))
+	}
+
+	entries := s.Entries(&debugz.Query{Until: t0.Add(time.Minute)})
+	require.Len(t, entries, 1)
+	require.Equal(t, t0, entries[0].Time)
+
+	groups := s.Groups(nil)
+	require.Len(t, groups, 1)
+	require.Equal(t, t0, groups[0].FirstSeen)
+	require.Equal(t, t0.Add(2*time.Minute), groups[0].LastSeen)
+
+	s.Add(newError(1))
+	require.True(t, s.Groups(nil)[0].LastSeen.After(t0.Add(2*time.Minute)))
+}
+
+func TestStoreSink(t *testing.T) {
+	s := debugz.NewStore(nil)
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{s}})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.Report(context.Background(), newError(i))
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, r.Close(context.Background()))
+	require.Len(t, s.Entries(nil), 10)
+	require.Len(t, s.Groups(nil), 1)
+	require.Equal(t, int64(10), s.Groups(nil)[0].Count)
+}
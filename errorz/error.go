@@ -3,16 +3,23 @@ package errorz
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"runtime"
 )
 
 type wrappedError struct {
-	err      error
-	id       ID
-	status   Status
-	metadata Metadata
-	prefix   string
-	callers  []uintptr
+	err           error
+	id            ID
+	code          Code
+	canonicalCode *CanonicalCode
+	status        Status
+	severity      Severity
+	retryable     *bool
+	headers       http.Header
+	metadata      Metadata
+	fieldErrors   FieldErrors
+	prefix        string
+	callers       []uintptr
 }
 
 // Error implements the error interface.
@@ -20,6 +27,11 @@ func (e *wrappedError) Error() string {
 	return e.prefix + e.err.Error()
 }
 
+// Unwrap returns the wrapped error, allowing inspection using errors.Is and errors.As.
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
 // Wrap wraps the given error, applying the given options.
 func Wrap(err error, options ...Option) error {
 	if err == nil {
@@ -40,6 +52,10 @@ func Wrap(err error, options ...Option) error {
 		option.Apply(e)
 	}
 
+	if !ok {
+		wrapHooks.call(e)
+	}
+
 	return e
 }
 
@@ -60,7 +76,9 @@ func MustWrap(err error, options ...Option) {
 	}
 
 	options = append(options, Skip())
-	panic(Wrap(err, options...))
+	err = Wrap(err, options...)
+	mustHooks.call(err)
+	panic(err)
 }
 
 // MaybeMustWrap is like MustWrap, but does nothing if called with a nil error.
@@ -81,14 +99,18 @@ func WrapRecover(r interface{}, options ...Option) error {
 
 	options = append(options, Skip())
 
+	var err error
 	switch r := r.(type) {
 	case *wrappedError:
-		return r
+		err = r
 	case error:
-		return Wrap(r, options...)
+		err = Wrap(r, options...)
 	default:
-		return Wrap(fmt.Errorf("%v", r), options...)
+		err = Wrap(fmt.Errorf("%v", r), options...)
 	}
+
+	recoverHooks.call(err)
+	return err
 }
 
 // MaybeWrapRecover is like WrapRecover but returns nil if called with a nil recover.
@@ -118,7 +140,9 @@ func Errorf(format string, options ...Option) error {
 // MustErrorf is like Errorf but panics instead of returning the error.
 func MustErrorf(format string, options ...Option) {
 	options = append(options, Skip())
-	panic(Errorf(format, options...))
+	err := Errorf(format, options...)
+	mustHooks.call(err)
+	panic(err)
 }
 
 // Assertf is like MustErrorf if cond is false, does nothing otherwise.
@@ -153,6 +177,28 @@ func Unwrap(err error) error {
 	return err
 }
 
+// Clone returns a copy of the given errorz error, such that applying options to it (e.g. using Wrap) doesn't affect the
+// original one, which is useful when the original error is shared. Other errors are returned unchanged, as Wrap
+// already allocates a new error for them.
+func Clone(err error) error {
+	e, ok := err.(*wrappedError)
+	if !ok {
+		return err
+	}
+
+	c := *e
+	c.metadata = make(Metadata, len(e.metadata))
+	for k, v := range e.metadata {
+		c.metadata[k] = v
+	}
+	if e.headers != nil {
+		c.headers = e.headers.Clone()
+	}
+	c.fieldErrors = append(FieldErrors(nil), e.fieldErrors...)
+	c.callers = append([]uintptr(nil), e.callers...)
+	return &c
+}
+
 // Safe calls the function catching any panic and returning it as error.
 func Safe(f func() error) func() error {
 	return func() (err error) {
@@ -1,6 +1,8 @@
 package errorz_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -140,6 +142,24 @@ func TestUnwrap(t *testing.T) {
 	require.Equal(t, ret, err)
 	ret = errorz.Unwrap(errorz.Wrap(err))
 	require.Equal(t, ret, err)
+	require.True(t, errors.Is(errorz.Wrap(err), err))
+}
+
+func TestClone(t *testing.T) {
+	plainErr := fmt.Errorf("test error")
+	require.Equal(t, plainErr, errorz.Clone(plainErr))
+
+	err := errorz.Errorf("test error", errorz.ID("id"), errorz.M("k", "v"), errorz.Header("h", "v"))
+	clonedErr := errorz.Wrap(errorz.Clone(err), errorz.ID("other"), errorz.M("k", "other"), errorz.Header("h", "other"), errorz.Skip())
+	require.Equal(t, "test error", clonedErr.Error())
+	require.Equal(t, errorz.ID("other"), errorz.GetID(clonedErr))
+	require.Equal(t, "other", errorz.GetMetadata(clonedErr).Get("k"))
+	require.Equal(t, "other", errorz.GetHeaders(clonedErr).Get("h"))
+	require.Equal(t, errorz.ID("id"), errorz.GetID(err))
+	require.Equal(t, "v", errorz.GetMetadata(err).Get("k"))
+	require.Equal(t, "v", errorz.GetHeaders(err).Get("h"))
+	require.Equal(t, errorz.FormatStackTrace(errorz.GetCallers(err))[1:], errorz.FormatStackTrace(errorz.GetCallers(clonedErr)))
+	require.True(t, errors.Is(clonedErr, errorz.Unwrap(err)))
 }
 
 func TestSafe(t *testing.T) {
@@ -159,6 +179,56 @@ func TestID(t *testing.T) {
 	require.Equal(t, errorz.ID("id"), errorz.GetID(err))
 }
 
+func TestIDParent(t *testing.T) {
+	require.Equal(t, errorz.ID("db.conn"), errorz.ID("db.conn.timeout").Parent())
+	require.Equal(t, errorz.ID("db"), errorz.ID("db.conn").Parent())
+	require.Equal(t, errorz.ID("db/conn"), errorz.ID("db/conn/timeout").Parent())
+	require.Equal(t, errorz.ID("db/conn"), errorz.ID("db/conn.timeout").Parent())
+	require.Equal(t, errorz.ID(""), errorz.ID("db").Parent())
+	require.Equal(t, errorz.ID(""), errorz.ID("").Parent())
+}
+
+func TestIDHasPrefix(t *testing.T) {
+	require.True(t, errorz.ID("db.conn.timeout").HasPrefix(""))
+	require.True(t, errorz.ID("db.conn.timeout").HasPrefix("db."))
+	require.True(t, errorz.ID("db.conn.timeout").HasPrefix("db"))
+	require.True(t, errorz.ID("db.conn.timeout").HasPrefix("db.conn"))
+	require.True(t, errorz.ID("db.conn.timeout").HasPrefix("db.conn.timeout"))
+	require.True(t, errorz.ID("db/conn").HasPrefix("db/"))
+	require.True(t, errorz.ID("db/conn").HasPrefix("db"))
+	require.False(t, errorz.ID("dbx.conn").HasPrefix("db"))
+	require.False(t, errorz.ID("dbx.conn").HasPrefix("db."))
+	require.False(t, errorz.ID("db").HasPrefix("db."))
+	require.False(t, errorz.ID("db.conn").HasPrefix("db.conn.timeout"))
+}
+
+func TestHasIDPrefix(t *testing.T) {
+	require.True(t, errorz.HasIDPrefix(errorz.Errorf("test error", errorz.ID("db.conn.timeout")), "db."))
+	require.False(t, errorz.HasIDPrefix(errorz.Errorf("test error", errorz.ID("http.timeout")), "db."))
+	require.False(t, errorz.HasIDPrefix(errorz.Errorf("test error"), ""))
+	require.False(t, errorz.HasIDPrefix(fmt.Errorf("test error"), ""))
+}
+
+func TestNamespaceDefaults(t *testing.T) {
+	errorz.SetNamespaceDefaults("ns-test", errorz.NamespaceDefaults{Status: http.StatusInternalServerError})
+	errorz.SetNamespaceDefaults("ns-test.conn", errorz.NamespaceDefaults{Status: http.StatusServiceUnavailable})
+	errorz.SetNamespaceDefaults("ns-test.conn.empty", errorz.NamespaceDefaults{})
+	defer errorz.ClearNamespaceDefaults("ns-test")
+	defer errorz.ClearNamespaceDefaults("ns-test.conn")
+	defer errorz.ClearNamespaceDefaults("ns-test.conn.empty")
+
+	require.Equal(t, errorz.NamespaceDefaults{}, errorz.GetNamespaceDefaults(""))
+	require.Equal(t, errorz.NamespaceDefaults{}, errorz.GetNamespaceDefaults("other"))
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.GetNamespaceDefaults("ns-test").Status)
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), errorz.GetNamespaceDefaults("ns-test.query").Status)
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetNamespaceDefaults("ns-test.conn.timeout").Status)
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetNamespaceDefaults("ns-test.conn.empty").Status)
+
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetStatus(errorz.Errorf("test error", errorz.ID("ns-test.conn.timeout"))))
+	require.Equal(t, errorz.Status(http.StatusBadRequest), errorz.GetStatus(errorz.Errorf("test error", errorz.ID("ns-test.conn.timeout"), errorz.Status(http.StatusBadRequest))))
+	require.Equal(t, errorz.Status(0), errorz.GetStatus(errorz.Errorf("test error", errorz.ID("other"))))
+}
+
 func TestStatus(t *testing.T) {
 	require.Equal(t, errorz.Status(0), errorz.GetStatus(errorz.Errorf("test error")))
 	require.Equal(t, errorz.Status(0), errorz.GetStatus(fmt.Errorf("test error")))
@@ -171,6 +241,19 @@ func TestStatus(t *testing.T) {
 	require.Equal(t, 50, errorz.Status(50).Int())
 }
 
+func TestGetExplicitStatus(t *testing.T) {
+	_, ok := errorz.GetExplicitStatus(context.DeadlineExceeded)
+	require.False(t, ok)
+	require.Equal(t, errorz.Status(http.StatusGatewayTimeout), errorz.GetStatus(context.DeadlineExceeded))
+
+	_, ok = errorz.GetExplicitStatus(errorz.Errorf("test error"))
+	require.False(t, ok)
+
+	status, ok := errorz.GetExplicitStatus(errorz.Errorf("test error", errorz.Status(http.StatusNotFound)))
+	require.True(t, ok)
+	require.Equal(t, errorz.Status(http.StatusNotFound), status)
+}
+
 func TestMetadata(t *testing.T) {
 	require.Equal(t, errorz.Metadata{}, errorz.GetMetadata(errorz.Errorf("test error")))
 	require.Equal(t, errorz.Metadata{}, errorz.GetMetadata(fmt.Errorf("test error")))
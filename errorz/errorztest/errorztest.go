@@ -0,0 +1,124 @@
+// Package errorztest provides test assertions for errorz errors.
+// On failure, they print the full formatted error (see errorz.Format) and stop the test.
+//
+// It also provides golden file helpers (see RequireGolden and RequireGoldenSummary). Golden files are created or
+// updated instead of compared when the "-update" flag is set, or when the ERRORZ_UPDATE_GOLDEN environment variable
+// is set to a true value. The flag is not registered by this package: test packages which want it declare it, e.g.
+//
+//	var _ = flag.Bool(errorztest.UpdateFlag, false, "update golden files")
+//
+// and run "go test ./... -update".
+package errorztest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+// RequireID requires the error to be non-nil and have the given id.
+func RequireID(t testing.TB, err error, id errorz.ID) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	if actual := errorz.GetID(err); actual != id {
+		fail(t, err, "unexpected id: expected %q, actual %q", id, actual)
+	}
+}
+
+// RequireStatus requires the error to be non-nil and have the given status.
+func RequireStatus(t testing.TB, err error, status errorz.Status) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	if actual := errorz.GetStatus(err); actual != status {
+		fail(t, err, "unexpected status: expected %v, actual %v", status, actual)
+	}
+}
+
+// RequireMetadata requires the error to be non-nil and have the given metadata keys with equal values.
+// Additional metadata keys on the error are ignored.
+func RequireMetadata(t testing.TB, err error, metadata errorz.Metadata) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	actual := errorz.GetMetadata(err)
+
+	for k, v := range metadata {
+		actualV, ok := actual[k]
+		if !ok {
+			fail(t, err, "missing metadata key %q: expected %#v", k, v)
+		}
+		if !reflect.DeepEqual(v, actualV) {
+			fail(t, err, "unexpected metadata value for key %q: expected %#v, actual %#v", k, v, actualV)
+		}
+	}
+}
+
+// RequireCause requires the error to be non-nil and have the given error in its chain (see errors.Is).
+func RequireCause(t testing.TB, err error, cause error) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	if !errors.Is(err, cause) {
+		fail(t, err, "cause not found in error chain: %v", cause)
+	}
+}
+
+// RequireStackContains requires the error to be non-nil and have a stack trace (anywhere in its chain, see
+// errorz.FindCallers) with a frame containing the given string.
+func RequireStackContains(t testing.TB, err error, s string) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	callers, ok := errorz.FindCallers(err)
+	if !ok {
+		fail(t, err, "no stack trace found in error chain")
+	}
+
+	for _, frame := range errorz.FormatStackTrace(callers) {
+		if strings.Contains(frame, s) {
+			return
+		}
+	}
+
+	fail(t, err, "no stack frame contains %q", s)
+}
+
+// RequirePanicsWithID requires f to panic with an error (or a value converted by errorz.WrapRecover) with the given
+// id.
+func RequirePanicsWithID(t testing.TB, id errorz.ID, f func()) {
+	t.Helper()
+
+	err := func() (err error) {
+		defer func() {
+			err = errorz.MaybeWrapRecover(recover())
+		}()
+		f()
+		return nil
+	}()
+
+	if err == nil {
+		t.Fatalf("expected panic with id %q, but function did not panic", id)
+		return
+	}
+
+	RequireID(t, err, id)
+}
+
+func requireNotNil(t testing.TB, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("expected error, actual nil")
+	}
+}
+
+func fail(t testing.TB, err error, format string, a ...interface{}) {
+	t.Helper()
+	t.Fatalf("%v\n\nactual error:\n%v", fmt.Sprintf(format, a...), errorz.Format(err))
+}
@@ -0,0 +1,104 @@
+package errorztest_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/errorztest"
+)
+
+type fatalError string
+
+type fakeTB struct {
+	testing.TB
+}
+
+// Helper implements the testing.TB interface.
+func (*fakeTB) Helper() {
+	// intentionally empty
+}
+
+// Fatalf implements the testing.TB interface.
+func (*fakeTB) Fatalf(format string, a ...interface{}) {
+	panic(fatalError(fmt.Sprintf(format, a...)))
+}
+
+func capture(f func(t testing.TB)) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = string(r.(fatalError))
+		}
+	}()
+	f(&fakeTB{})
+	return ""
+}
+
+func TestRequireID(t *testing.T) {
+	err := errorz.Errorf("test error", errorz.ID("id"), errorz.M("k", "v"))
+	errorztest.RequireID(t, err, "id")
+
+	msg := capture(func(t testing.TB) { errorztest.RequireID(t, err, "other") })
+	require.Contains(t, msg, `unexpected id: expected "other", actual "id"`)
+	require.Contains(t, msg, "actual error:\ntest error\n  id: id\n")
+	require.Contains(t, msg, "    k: v\n")
+	require.Contains(t, msg, "errorztest_test.TestRequireID")
+
+	msg = capture(func(t testing.TB) { errorztest.RequireID(t, nil, "id") })
+	require.Equal(t, "expected error, actual nil", msg)
+}
+
+func TestRequireStatus(t *testing.T) {
+	err := errorz.Errorf("test error", errorz.Status(http.StatusNotFound))
+	errorztest.RequireStatus(t, err, http.StatusNotFound)
+
+	msg := capture(func(t testing.TB) { errorztest.RequireStatus(t, err, http.StatusOK) })
+	require.Contains(t, msg, "unexpected status: expected 200, actual 404")
+}
+
+func TestRequireMetadata(t *testing.T) {
+	err := errorz.Errorf("test error", errorz.M("k1", "v1"), errorz.M("k2", 2))
+	errorztest.RequireMetadata(t, err, errorz.Metadata{"k1": "v1"})
+	errorztest.RequireMetadata(t, err, errorz.Metadata{"k1": "v1", "k2": 2})
+
+	msg := capture(func(t testing.TB) { errorztest.RequireMetadata(t, err, errorz.Metadata{"k3": "v3"}) })
+	require.Contains(t, msg, `missing metadata key "k3": expected "v3"`)
+
+	msg = capture(func(t testing.TB) { errorztest.RequireMetadata(t, err, errorz.Metadata{"k2": "2"}) })
+	require.Contains(t, msg, `unexpected metadata value for key "k2": expected "2", actual 2`)
+}
+
+func TestRequireCause(t *testing.T) {
+	err := errorz.Wrap(fmt.Errorf("wrapped: %w", io.EOF))
+	errorztest.RequireCause(t, err, io.EOF)
+
+	msg := capture(func(t testing.TB) { errorztest.RequireCause(t, err, io.ErrUnexpectedEOF) })
+	require.Contains(t, msg, "cause not found in error chain: unexpected EOF")
+}
+
+func TestRequireStackContains(t *testing.T) {
+	err := errorz.Errorf("test error")
+	errorztest.RequireStackContains(t, err, "TestRequireStackContains")
+
+	msg := capture(func(t testing.TB) { errorztest.RequireStackContains(t, err, "Unknown") })
+	require.Contains(t, msg, `no stack frame contains "Unknown"`)
+
+	errorztest.RequireStackContains(t, fmt.Errorf("wrapped: %w", err), "TestRequireStackContains")
+
+	msg = capture(func(t testing.TB) { errorztest.RequireStackContains(t, fmt.Errorf("test error"), "testing.tRunner") })
+	require.Contains(t, msg, "no stack trace found in error chain")
+}
+
+func TestRequirePanicsWithID(t *testing.T) {
+	errorztest.RequirePanicsWithID(t, "id", func() { errorz.MustErrorf("test error", errorz.ID("id")) })
+
+	msg := capture(func(t testing.TB) { errorztest.RequirePanicsWithID(t, "id", func() {}) })
+	require.Equal(t, `expected panic with id "id", but function did not panic`, msg)
+
+	msg = capture(func(t testing.TB) { errorztest.RequirePanicsWithID(t, "id", func() { panic("other") }) })
+	require.Contains(t, msg, `unexpected id: expected "id", actual ""`)
+}
@@ -0,0 +1,82 @@
+package errorztest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	// UpdateFlag is the name of the command line flag which enables updating golden files, e.g.
+	// "go test ./... -update". This package does not register it, so as not to conflict with user flags: test
+	// packages which want it declare it themselves (see the package documentation).
+	UpdateFlag = "update"
+
+	// UpdateEnvVar is the name of the environment variable which enables updating golden files when UpdateFlag is
+	// not registered or not set, e.g. "ERRORZ_UPDATE_GOLDEN=1 go test ./...".
+	UpdateEnvVar = "ERRORZ_UPDATE_GOLDEN"
+)
+
+// IsUpdate returns true if golden files should be updated, i.e. if UpdateFlag is registered on the command line
+// and set to true, or if UpdateEnvVar is set to a true value (see strconv.ParseBool). The flag is looked up at call
+// time, as flags are only parsed once the test binary starts.
+func IsUpdate() bool {
+	if f := flag.Lookup(UpdateFlag); f != nil {
+		if isUpdate, _ := strconv.ParseBool(f.Value.String()); isUpdate {
+			return true
+		}
+	}
+
+	isUpdate, _ := strconv.ParseBool(os.Getenv(UpdateEnvVar))
+	return isUpdate
+}
+
+// RequireGolden requires the given value to be equal to the contents of the golden file at the given path.
+// If IsUpdate returns true, the golden file (and its directory) is created or updated instead.
+func RequireGolden(t testing.TB, path string, actual []byte) {
+	t.Helper()
+
+	if IsUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatalf("unable to create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, actual, 0666); err != nil {
+			t.Fatalf("unable to write golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read golden file (run with -%v or %v=1 to create it): %v", UpdateFlag, UpdateEnvVar, err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("golden file mismatch (run with -%v or %v=1 to update it): %v\n\nexpected:\n%s\n\nactual:\n%s",
+			UpdateFlag, UpdateEnvVar, path, expected, actual)
+	}
+}
+
+// RequireGoldenSummary requires the normalized summary of the error (see errorz.Summary.Normalize), serialized as
+// indented JSON, to match the golden file at the given path.
+func RequireGoldenSummary(t testing.TB, path string, err error) {
+	t.Helper()
+	requireNotNil(t, err)
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if jsonErr := enc.Encode(errorz.ToSummary(err).Normalize()); jsonErr != nil {
+		fail(t, err, "unable to serialize summary: %v", jsonErr)
+	}
+
+	RequireGolden(t, path, buf.Bytes())
+}
@@ -0,0 +1,75 @@
+package errorztest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/errorztest"
+)
+
+var _ = flag.Bool(errorztest.UpdateFlag, false, "update golden files")
+
+func TestRequireGoldenSummary(t *testing.T) {
+	errorztest.RequireGoldenSummary(t, filepath.Join("testdata", "summary.golden.json"),
+		errorz.Errorf("test error", errorz.ID("id"), errorz.Status(404), errorz.M("k", "v")))
+}
+
+func TestRequireGolden(t *testing.T) {
+	setUpdateFlag(t, "false")
+	t.Setenv(errorztest.UpdateEnvVar, "")
+	path := filepath.Join(t.TempDir(), "dir", "file.golden")
+
+	msg := capture(func(t testing.TB) { errorztest.RequireGolden(t, path, []byte("value")) })
+	require.Contains(t, msg, "unable to read golden file (run with -update or ERRORZ_UPDATE_GOLDEN=1 to create it)")
+
+	t.Setenv(errorztest.UpdateEnvVar, "true")
+	require.True(t, errorztest.IsUpdate())
+	errorztest.RequireGolden(t, path, []byte("value"))
+
+	t.Setenv(errorztest.UpdateEnvVar, "0")
+	require.False(t, errorztest.IsUpdate())
+
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "value", string(buf))
+
+	errorztest.RequireGolden(t, path, []byte("value"))
+	msg = capture(func(t testing.TB) { errorztest.RequireGolden(t, path, []byte("other")) })
+	require.Contains(t, msg, "golden file mismatch (run with -update or ERRORZ_UPDATE_GOLDEN=1 to update it)")
+	require.Contains(t, msg, "expected:\nvalue\n\nactual:\nother")
+
+	msg = capture(func(t testing.TB) { errorztest.RequireGoldenSummary(t, path, nil) })
+	require.Equal(t, "expected error, actual nil", msg)
+}
+
+func TestIsUpdateFlag(t *testing.T) {
+	setUpdateFlag(t, "false")
+	t.Setenv(errorztest.UpdateEnvVar, "")
+	require.False(t, errorztest.IsUpdate())
+
+	t.Setenv(errorztest.UpdateEnvVar, "1")
+	require.True(t, errorztest.IsUpdate())
+	t.Setenv(errorztest.UpdateEnvVar, "")
+
+	setUpdateFlag(t, "true")
+	require.True(t, errorztest.IsUpdate())
+
+	path := filepath.Join(t.TempDir(), "file.golden")
+	errorztest.RequireGolden(t, path, []byte("value"))
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "value", string(buf))
+}
+
+func setUpdateFlag(t *testing.T, value string) {
+	f := flag.Lookup(errorztest.UpdateFlag)
+	require.NotNil(t, f)
+	prev := f.Value.String()
+	require.NoError(t, f.Value.Set(value))
+	t.Cleanup(func() { require.NoError(t, f.Value.Set(prev)) })
+}
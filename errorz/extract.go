@@ -0,0 +1,131 @@
+package errorz
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"sync"
+)
+
+var (
+	extractorsM = &sync.RWMutex{}
+	extractors  = []*Extractor{}
+)
+
+// Extractor extracts metadata from an error, returning nil if it doesn't recognize it.
+// Extractors are called on each error of the chain individually, so they should not unwrap errors.
+type Extractor func(err error) Metadata
+
+// RegisterExtractor registers an extractor, returning a function which unregisters it.
+// Registered extractors are tried in reverse registration order, before the built-in ones.
+func RegisterExtractor(x Extractor) func() {
+	extractorsM.Lock()
+	defer extractorsM.Unlock()
+
+	p := &x
+	extractors = append(extractors, p)
+
+	return func() {
+		extractorsM.Lock()
+		defer extractorsM.Unlock()
+
+		for i, other := range extractors {
+			if other == p {
+				extractors = append(extractors[:i:i], extractors[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ExtractMetadata extracts metadata from all the errors in the chain, using the registered and built-in extractors.
+// When multiple errors provide the same key, the outermost one wins. On each error, registered extractors are tried
+// before the built-in ones, so that they can override them (the same precedence as classifiers, see
+// GetClassification). Extractors are called without holding any lock, so they can use the rest of the package.
+//
+// The built-in extractors use the following keys:
+//   - *fs.PathError: "path-op", "path";
+//   - *net.OpError: "net-op", "net", "net-addr" (if known), "net-source" (if known);
+//   - *url.Error: "url-op", "url" (with secrets redacted, see RedactURL);
+//   - *json.SyntaxError: "json-offset";
+//   - *json.UnmarshalTypeError: "json-field", "json-type", "json-value", "json-offset";
+//   - *strconv.NumError: "num-func", "num-value";
+//   - *exec.ExitError: "exit-code".
+func ExtractMetadata(err error) Metadata {
+	extractorsM.RLock()
+	registered := extractors
+	extractorsM.RUnlock()
+
+	m := Metadata{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		for i := len(registered) - 1; i >= 0; i-- {
+			mergeMissing(m, (*registered[i])(err))
+		}
+
+		mergeMissing(m, extractBuiltIn(err))
+	}
+
+	return m
+}
+
+// Extract attaches the metadata extracted from the error chain (see ExtractMetadata), without overwriting any key
+// already set on the error.
+func Extract() OptionFunc {
+	return func(err error) {
+		if e, ok := err.(*wrappedError); ok {
+			mergeMissing(e.metadata, ExtractMetadata(e.err))
+		}
+	}
+}
+
+func extractBuiltIn(err error) Metadata {
+	switch err := err.(type) {
+	case *fs.PathError:
+		return Metadata{"path-op": err.Op, "path": err.Path}
+	case *net.OpError:
+		m := Metadata{"net-op": err.Op, "net": err.Net}
+		if err.Addr != nil {
+			m["net-addr"] = err.Addr.String()
+		}
+		if err.Source != nil {
+			m["net-source"] = err.Source.String()
+		}
+		return m
+	case *url.Error:
+		return Metadata{"url-op": err.Op, "url": redactURLString(err.URL)}
+	case *json.SyntaxError:
+		return Metadata{"json-offset": err.Offset}
+	case *json.UnmarshalTypeError:
+		m := Metadata{"json-field": err.Field, "json-value": err.Value, "json-offset": err.Offset}
+		if err.Type != nil {
+			m["json-type"] = err.Type.String()
+		}
+		return m
+	case *strconv.NumError:
+		return Metadata{"num-func": err.Func, "num-value": err.Num}
+	case *exec.ExitError:
+		return Metadata{"exit-code": err.ExitCode()}
+	default:
+		return nil
+	}
+}
+
+func redactURLString(s string) string {
+	if u, err := url.Parse(s); err == nil {
+		return RedactURL(u, nil)
+	}
+	return s
+}
+
+func mergeMissing(dst, src Metadata) {
+	for k, v := range src {
+		if _, ok := dst[k]; !ok {
+			dst[k] = v
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package faultz provides named fault injection points, for testing error paths.
+//
+// Injection points are placed in production code using Point or PointCtx: they return nil unless armed, which only
+// happens in tests. Points armed globally using Arm are disarmed when the test completes. Since global arming affects
+// every goroutine, parallel tests should arm points on their context using WithFault and use PointCtx instead.
+package faultz
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type contextKey int
+
+const (
+	faultsContextKey contextKey = iota
+)
+
+var (
+	armedCount = int64(0)
+	armedM     = &sync.Mutex{}
+	armed      = map[string]*armedFault{}
+)
+
+// Fault describes the behavior of an armed injection point.
+type Fault struct {
+	// Err is returned by the injection point, wrapped with the "fault-point" metadata key. Errorz errors are cloned on
+	// each call (see errorz.Clone), so the same error can be returned concurrently.
+	Err error
+	// Panic, if non-nil, makes the injection point panic with the given value instead.
+	Panic interface{}
+	// Delay is waited before returning or panicking (or until the context is done, for PointCtx).
+	Delay time.Duration
+	// OnCall, if positive, makes the injection point fail only on the Nth call after arming (starting from 1).
+	OnCall int
+}
+
+// TB describes the subset of testing.TB used by Arm, so that production code doesn't have to import testing.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+type armedFault struct {
+	fault *Fault
+	calls int64
+}
+
+// Arm arms the named injection point globally until the test completes. It fails the test if the point is already
+// armed, e.g. by a concurrent test.
+func Arm(t TB, name string, fault *Fault) {
+	t.Helper()
+
+	armedM.Lock()
+	defer armedM.Unlock()
+
+	if _, ok := armed[name]; ok {
+		t.Fatalf("faultz: point %q is already armed", name)
+		return
+	}
+
+	armed[name] = &armedFault{fault: fault}
+	atomic.AddInt64(&armedCount, 1)
+
+	t.Cleanup(func() {
+		armedM.Lock()
+		defer armedM.Unlock()
+		delete(armed, name)
+		atomic.AddInt64(&armedCount, -1)
+	})
+}
+
+// WithFault returns a copy of the context with the named injection point armed, for use with PointCtx.
+// Context-scoped faults take precedence over the ones armed using Arm.
+func WithFault(ctx context.Context, name string, fault *Fault) context.Context {
+	faults := map[string]*armedFault{}
+	if parent, ok := ctx.Value(faultsContextKey).(map[string]*armedFault); ok {
+		for k, v := range parent {
+			faults[k] = v
+		}
+	}
+	faults[name] = &armedFault{fault: fault}
+	return context.WithValue(ctx, faultsContextKey, faults)
+}
+
+// Point is a named injection point. It returns nil unless armed using Arm.
+func Point(name string) error {
+	if atomic.LoadInt64(&armedCount) == 0 {
+		return nil
+	}
+	return trigger(context.Background(), name, getArmed(name))
+}
+
+// PointCtx is like Point, but it also considers faults armed on the context using WithFault.
+func PointCtx(ctx context.Context, name string) error {
+	if faults, ok := ctx.Value(faultsContextKey).(map[string]*armedFault); ok {
+		if f, ok := faults[name]; ok {
+			return trigger(ctx, name, f)
+		}
+	}
+
+	if atomic.LoadInt64(&armedCount) == 0 {
+		return nil
+	}
+	return trigger(ctx, name, getArmed(name))
+}
+
+func getArmed(name string) *armedFault {
+	armedM.Lock()
+	defer armedM.Unlock()
+	return armed[name]
+}
+
+func trigger(ctx context.Context, name string, f *armedFault) error {
+	if f == nil {
+		return nil
+	}
+
+	calls := atomic.AddInt64(&f.calls, 1)
+	if f.fault.OnCall > 0 && calls != int64(f.fault.OnCall) {
+		return nil
+	}
+
+	if f.fault.Delay > 0 {
+		timer := time.NewTimer(f.fault.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return errorz.Wrap(ctx.Err(), errorz.M("fault-point", name), errorz.SkipPackage())
+		case <-timer.C:
+		}
+	}
+
+	if f.fault.Panic != nil {
+		panic(f.fault.Panic)
+	}
+
+	if f.fault.Err != nil {
+		return errorz.Wrap(errorz.Clone(f.fault.Err), errorz.M("fault-point", name), errorz.SkipPackage())
+	}
+
+	return nil
+}
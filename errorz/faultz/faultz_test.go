@@ -0,0 +1,176 @@
+package faultz_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/faultz"
+)
+
+type fakeTB struct {
+	fatal    string
+	cleanups []func()
+}
+
+// Helper implements the faultz.TB interface.
+func (*fakeTB) Helper() {
+	// intentionally empty
+}
+
+// Fatalf implements the faultz.TB interface.
+func (t *fakeTB) Fatalf(format string, args ...interface{}) {
+	t.fatal = fmt.Sprintf(format, args...)
+}
+
+// Cleanup implements the faultz.TB interface.
+func (t *fakeTB) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *fakeTB) runCleanups() {
+	for _, f := range t.cleanups {
+		f()
+	}
+}
+
+func TestPoint(t *testing.T) {
+	require.NoError(t, faultz.Point("faultz-test.point"))
+
+	tb := &fakeTB{}
+	faultz.Arm(tb, "faultz-test.point", &faultz.Fault{Err: errorz.Errorf("injected", errorz.Status(http.StatusServiceUnavailable))})
+
+	err := faultz.Point("faultz-test.point")
+	require.EqualError(t, err, "injected")
+	require.Equal(t, errorz.Status(http.StatusServiceUnavailable), errorz.GetStatus(err))
+	require.Equal(t, "faultz-test.point", errorz.GetMetadata(err).Get("fault-point"))
+	require.NoError(t, faultz.Point("faultz-test.other"))
+
+	faultz.Arm(tb, "faultz-test.point", &faultz.Fault{})
+	require.Equal(t, `faultz: point "faultz-test.point" is already armed`, tb.fatal)
+
+	tb.runCleanups()
+	require.NoError(t, faultz.Point("faultz-test.point"))
+}
+
+func TestPointOnCall(t *testing.T) {
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+	faultz.Arm(tb, "faultz-test.on-call", &faultz.Fault{Err: fmt.Errorf("injected"), OnCall: 2})
+
+	require.NoError(t, faultz.Point("faultz-test.on-call"))
+	err := faultz.Point("faultz-test.on-call")
+	require.EqualError(t, err, "injected")
+	require.Equal(t, "faultz-test.on-call", errorz.GetMetadata(err).Get("fault-point"))
+	require.NoError(t, faultz.Point("faultz-test.on-call"))
+}
+
+func TestPointPanicDelay(t *testing.T) {
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+	faultz.Arm(tb, "faultz-test.panic", &faultz.Fault{Panic: "injected panic", Delay: time.Millisecond})
+	faultz.Arm(tb, "faultz-test.delay", &faultz.Fault{Delay: 10 * time.Millisecond})
+
+	require.PanicsWithValue(t, "injected panic", func() { _ = faultz.Point("faultz-test.panic") })
+
+	start := time.Now()
+	require.NoError(t, faultz.Point("faultz-test.delay"))
+	require.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := faultz.PointCtx(ctx, "faultz-test.delay")
+	require.EqualError(t, err, "context canceled")
+	require.Equal(t, "faultz-test.delay", errorz.GetMetadata(err).Get("fault-point"))
+}
+
+func TestPointCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := faultz.WithFault(context.Background(), "faultz-test.ctx", &faultz.Fault{Err: fmt.Errorf("ctx 1")})
+	ctx = faultz.WithFault(ctx, "faultz-test.ctx-2", &faultz.Fault{Err: fmt.Errorf("ctx 2")})
+
+	require.EqualError(t, faultz.PointCtx(ctx, "faultz-test.ctx"), "ctx 1")
+	require.EqualError(t, faultz.PointCtx(ctx, "faultz-test.ctx-2"), "ctx 2")
+	require.NoError(t, faultz.PointCtx(ctx, "faultz-test.other"))
+	require.NoError(t, faultz.PointCtx(context.Background(), "faultz-test.ctx"))
+	require.NoError(t, faultz.Point("faultz-test.ctx"))
+
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+	faultz.Arm(tb, "faultz-test.global", &faultz.Fault{Err: fmt.Errorf("global")})
+	require.EqualError(t, faultz.PointCtx(ctx, "faultz-test.global"), "global")
+}
+
+func TestPointConcurrent(t *testing.T) {
+	t.Parallel()
+
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+	faultz.Arm(tb, "faultz-test.concurrent", &faultz.Fault{Err: fmt.Errorf("injected"), OnCall: 50})
+
+	wg := &sync.WaitGroup{}
+	errs := make(chan error, 100)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := faultz.Point("faultz-test.concurrent"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	require.Len(t, errs, 1)
+}
+
+func TestArmTestingT(t *testing.T) {
+	t.Run("armed", func(t *testing.T) {
+		faultz.Arm(t, "faultz-test.testing", &faultz.Fault{Err: fmt.Errorf("injected")})
+		require.Error(t, faultz.Point("faultz-test.testing"))
+	})
+	require.NoError(t, faultz.Point("faultz-test.testing"))
+}
+
+func TestPointConcurrentErrorz(t *testing.T) {
+	t.Parallel()
+
+	injectedErr := errorz.Errorf("injected", errorz.ID("injected"))
+
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+	faultz.Arm(tb, "faultz-test.concurrent-errorz", &faultz.Fault{Err: injectedErr})
+
+	wg := &sync.WaitGroup{}
+	errs := make(chan error, 50)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- faultz.Point("faultz-test.concurrent-errorz")
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.EqualError(t, err, "injected")
+		require.Equal(t, errorz.ID("injected"), errorz.GetID(err))
+		require.Equal(t, "faultz-test.concurrent-errorz", errorz.GetMetadata(err).Get("fault-point"))
+		require.True(t, errors.Is(err, errorz.Unwrap(injectedErr)))
+	}
+
+	require.Nil(t, errorz.GetMetadata(injectedErr).Get("fault-point"))
+}
@@ -0,0 +1,183 @@
+package errorz
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	defaultFingerprintOptions atomic.Pointer[FingerprintOptions]
+	errorzPkgPath             = reflect.TypeOf(wrappedError{}).PkgPath()
+	stdlibSrcDir              = getStdlibSrcDir()
+	mainModulePath            = getMainModulePath()
+)
+
+var (
+	fingerprintUUIDRegexp   = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	fingerprintHexRegexp    = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`)
+	fingerprintQuotedRegexp = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+	fingerprintNumberRegexp = regexp.MustCompile(`\b\d(?:[\w.]*\w)?`)
+)
+
+// FingerprintOptions describes how Fingerprint computes fingerprints.
+type FingerprintOptions struct {
+	// Frames is the number of in-app stack frames included (default 3, negative for none).
+	Frames int
+	// IncludeLines includes line numbers in the frames. By default they are excluded, so that unrelated edits don't
+	// change fingerprints.
+	IncludeLines bool
+	// InApp returns true if the given frame belongs to the application. By default frames from the standard library
+	// and this package are excluded.
+	InApp func(frame runtime.Frame) bool
+}
+
+// SetDefaultFingerprintOptions sets the options used by Fingerprint when called with nil options, including by
+// ToSummary. Passing nil restores the defaults.
+func SetDefaultFingerprintOptions(opts *FingerprintOptions) {
+	defaultFingerprintOptions.Store(opts)
+}
+
+// Fingerprint returns a deterministic fingerprint of the error, suitable for grouping occurrences of the same issue.
+// It is computed from:
+//   - the id of the error (see GetID), or the type of the innermost error if not set;
+//   - the message template, i.e. the message with quoted strings, numbers, hexadecimal values and UUIDs replaced by
+//     placeholders (see NormalizeMessage);
+//   - the top in-app frames of the stack trace of the outermost error created by this package in the chain, if any.
+//
+// If opts is nil the default options are used (see SetDefaultFingerprintOptions). It returns "" for nil errors.
+func Fingerprint(err error, opts *FingerprintOptions) string {
+	if err == nil {
+		return ""
+	}
+
+	if opts == nil {
+		opts = defaultFingerprintOptions.Load()
+	}
+	if opts == nil {
+		opts = &FingerprintOptions{}
+	}
+
+	h := sha256.New()
+
+	if id := GetID(err); id != "" {
+		fmt.Fprintf(h, "id:%v\n", id)
+	} else {
+		fmt.Fprintf(h, "type:%T\n", rootError(err))
+	}
+
+	fmt.Fprintf(h, "message:%v\n", NormalizeMessage(err.Error()))
+
+	for _, frame := range getFingerprintFrames(err, opts) {
+		fmt.Fprintf(h, "frame:%v\n", frame)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// NormalizeMessage replaces the variable parts of an error message with placeholders: UUIDs with "<uuid>",
+// hexadecimal values with "<hex>", quoted strings with "<str>" and numbers (including units and dotted forms, e.g.
+// "1.5s" or "10.0.0.1") with "<num>".
+func NormalizeMessage(msg string) string {
+	msg = fingerprintUUIDRegexp.ReplaceAllString(msg, "<uuid>")
+	msg = fingerprintHexRegexp.ReplaceAllString(msg, "<hex>")
+	msg = fingerprintQuotedRegexp.ReplaceAllString(msg, "<str>")
+	return fingerprintNumberRegexp.ReplaceAllString(msg, "<num>")
+}
+
+func getFingerprintFrames(err error, opts *FingerprintOptions) []string {
+	maxFrames := opts.Frames
+	if maxFrames == 0 {
+		maxFrames = 3
+	}
+
+	var e *wrappedError
+	if maxFrames < 0 || !errors.As(err, &e) || len(e.callers) == 0 {
+		return nil
+	}
+
+	inApp := opts.InApp
+	if inApp == nil {
+		inApp = isInAppFrame
+	}
+
+	frames := runtime.CallersFrames(e.callers)
+	fingerprintFrames := make([]string, 0, maxFrames)
+
+	for len(fingerprintFrames) < maxFrames {
+		frame, more := frames.Next()
+
+		if frame.Function != "" && inApp(frame) {
+			if opts.IncludeLines {
+				fingerprintFrames = append(fingerprintFrames, frame.Function+":"+strconv.Itoa(frame.Line))
+			} else {
+				fingerprintFrames = append(fingerprintFrames, frame.Function)
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return fingerprintFrames
+}
+
+func isInAppFrame(frame runtime.Frame) bool {
+	pkg := getPackageFromFuncName(frame.Function)
+
+	if pkg == errorzPkgPath || strings.HasPrefix(pkg, errorzPkgPath+"/") {
+		return false
+	}
+
+	if stdlibSrcDir != "" {
+		return !strings.HasPrefix(frame.File, stdlibSrcDir)
+	}
+
+	// File paths are trimmed (e.g. "go build -trimpath"), so standard library packages are told apart by their path,
+	// which has no dot in its first element, unlike most module paths except the main one.
+	first := pkg
+	if i := strings.Index(first, "/"); i >= 0 {
+		first = first[:i]
+	}
+
+	return pkg == "main" || strings.Contains(first, ".") ||
+		(mainModulePath != "" && (pkg == mainModulePath || strings.HasPrefix(pkg, mainModulePath+"/")))
+}
+
+// getStdlibSrcDir returns the directory of the standard library sources as recorded in stack frames, with a trailing
+// slash, or "" if file paths are trimmed.
+func getStdlibSrcDir() string {
+	file, _ := runtime.FuncForPC(reflect.ValueOf(strconv.Itoa).Pointer()).FileLine(0)
+	if dir := path.Dir(path.Dir(file)); filepath.IsAbs(dir) {
+		return dir + "/"
+	}
+	return ""
+}
+
+func getMainModulePath() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}
+
+func rootError(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -0,0 +1,43 @@
+package errorz
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsInAppFrame(t *testing.T) {
+	defer func(dir string) { stdlibSrcDir = dir }(stdlibSrcDir)
+	stdlibSrcDir = "/usr/local/go/src/"
+
+	require.True(t, isInAppFrame(runtime.Frame{Function: "main.main", File: "/src/myservice/main.go"}))
+	require.True(t, isInAppFrame(runtime.Frame{Function: "myservice/store.(*DB).Get", File: "/src/myservice/store/db.go"}))
+	require.True(t, isInAppFrame(runtime.Frame{Function: "example.com/pkg.F", File: "/go/pkg/mod/example.com/pkg/f.go"}))
+	require.False(t, isInAppFrame(runtime.Frame{Function: "net/http.HandlerFunc.ServeHTTP", File: stdlibSrcDir + "net/http/server.go"}))
+	require.False(t, isInAppFrame(runtime.Frame{Function: errorzPkgPath + ".Errorf", File: "/src/errorz/error.go"}))
+	require.False(t, isInAppFrame(runtime.Frame{Function: errorzPkgPath + "/httpz.WriteError", File: "/src/errorz/httpz/httpz.go"}))
+}
+
+func TestGetStdlibSrcDir(t *testing.T) {
+	file, _ := runtime.FuncForPC(reflect.ValueOf(strings.Index).Pointer()).FileLine(0)
+	if dir := getStdlibSrcDir(); dir != "" {
+		require.Equal(t, dir+"strings/strings.go", file)
+	} else {
+		require.Equal(t, "strings/strings.go", file)
+	}
+}
+
+func TestIsInAppFrameTrimmed(t *testing.T) {
+	defer func(dir, path string) { stdlibSrcDir, mainModulePath = dir, path }(stdlibSrcDir, mainModulePath)
+	stdlibSrcDir, mainModulePath = "", "myservice"
+
+	require.True(t, isInAppFrame(runtime.Frame{Function: "main.main", File: "myservice/main.go"}))
+	require.True(t, isInAppFrame(runtime.Frame{Function: "myservice.F", File: "myservice/f.go"}))
+	require.True(t, isInAppFrame(runtime.Frame{Function: "myservice/store.(*DB).Get", File: "myservice/store/db.go"}))
+	require.True(t, isInAppFrame(runtime.Frame{Function: "example.com/pkg.F", File: "example.com/pkg@v1.0.0/f.go"}))
+	require.False(t, isInAppFrame(runtime.Frame{Function: "myservicex.F", File: "myservicex/f.go"}))
+	require.False(t, isInAppFrame(runtime.Frame{Function: "net/http.HandlerFunc.ServeHTTP", File: "net/http/server.go"}))
+}
@@ -0,0 +1,82 @@
+package errorz_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func newFingerprintTestError(i int) error {
+	return errorz.Errorf("user %v not found in %q", errorz.A(i, fmt.Sprintf("table-%v", i)))
+}
+
+func TestFingerprint(t *testing.T) {
+	require.Equal(t, "", errorz.Fingerprint(nil, nil))
+
+	fps := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		fps = append(fps, errorz.Fingerprint(newFingerprintTestError(i), nil))
+	}
+	fp := fps[0]
+	require.Len(t, fp, 16)
+	require.Equal(t, fp, fps[1])
+
+	otherSite := errorz.Errorf("user %v not found in %q", errorz.A(1, "table"))
+	require.NotEqual(t, fp, errorz.Fingerprint(otherSite, nil))
+	require.Equal(t,
+		errorz.Fingerprint(newFingerprintTestError(1), &errorz.FingerprintOptions{Frames: -1}),
+		errorz.Fingerprint(otherSite, &errorz.FingerprintOptions{Frames: -1}))
+	require.Equal(t,
+		errorz.Fingerprint(newFingerprintTestError(1), &errorz.FingerprintOptions{InApp: func(runtime.Frame) bool { return false }}),
+		errorz.Fingerprint(otherSite, &errorz.FingerprintOptions{Frames: -1}))
+
+	require.NotEqual(t,
+		errorz.Fingerprint(newFingerprintTestError(1), nil),
+		errorz.Fingerprint(errorz.Wrap(newFingerprintTestError(1), errorz.ID("id")), nil))
+
+	require.NotEqual(t,
+		errorz.Fingerprint(fmt.Errorf("test error"), nil),
+		errorz.Fingerprint(&testClassifiedError{}, nil))
+	require.Equal(t,
+		errorz.Fingerprint(fmt.Errorf("test error 1"), nil),
+		errorz.Fingerprint(fmt.Errorf("test error 2"), nil))
+}
+
+func TestFingerprintIncludeLines(t *testing.T) {
+	errs := []error{
+		errorz.Errorf("test error"),
+		errorz.Errorf("test error"),
+	}
+
+	require.Equal(t, errorz.Fingerprint(errs[0], nil), errorz.Fingerprint(errs[1], nil))
+
+	opts := &errorz.FingerprintOptions{IncludeLines: true}
+	require.NotEqual(t, errorz.Fingerprint(errs[0], opts), errorz.Fingerprint(errs[1], opts))
+
+	errorz.SetDefaultFingerprintOptions(opts)
+	defer errorz.SetDefaultFingerprintOptions(nil)
+	require.NotEqual(t, errorz.ToSummary(errs[0]).Fingerprint, errorz.ToSummary(errs[1]).Fingerprint)
+}
+
+func TestNormalizeMessage(t *testing.T) {
+	for msg, expected := range map[string]string{
+		"":                          "",
+		"plain message":             "plain message",
+		"user 42 not found":         "user <num> not found",
+		"took 1.5s":                 "took <num>",
+		"retry in 1m30s.":           "retry in <num>.",
+		"value 1.5 out of range":    "value <num> out of range",
+		`open "/tmp/a b": denied`:   "open <str>: denied",
+		`key 'a\'b' missing`:        "key <str> missing",
+		"object 0xc000123abc freed": "object <hex> freed",
+		"id 123e4567-e89b-12d3-a456-426614174000": "id <uuid>",
+		"api v2 failed":      "api v2 failed",
+		"dial 10.0.0.1:8080": "dial <num>:<num>",
+	} {
+		require.Equal(t, expected, errorz.NormalizeMessage(msg), msg)
+	}
+}
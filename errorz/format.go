@@ -0,0 +1,118 @@
+package errorz
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+var (
+	filteredStackPrefixes = []string{"runtime.", "testing."}
+)
+
+// TB describes the subset of testing.TB used by the test helpers.
+type TB interface {
+	Helper()
+	Log(args ...interface{})
+	Failed() bool
+	Cleanup(func())
+}
+
+// Format renders the error in a readable multi-line format, including id, code, status, severity, metadata, field
+// errors, and stack trace (excluding runtime and testing frames, and only if an errorz error is found in the chain).
+func Format(err error) string {
+	s := ToSummary(err)
+	b := &strings.Builder{}
+
+	b.WriteString(s.Message)
+
+	if s.ID != "" {
+		fmt.Fprintf(b, "\n  id: %v", s.ID)
+	}
+	if s.Code != 0 {
+		fmt.Fprintf(b, "\n  code: %v", s.Code)
+	}
+	if s.Status != 0 {
+		fmt.Fprintf(b, "\n  status: %v", s.Status)
+	}
+	fmt.Fprintf(b, "\n  severity: %v", s.Severity)
+
+	if len(s.Metadata) > 0 {
+		keys := make([]string, 0, len(s.Metadata))
+		for k := range s.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("\n  metadata:")
+		for _, k := range keys {
+			fmt.Fprintf(b, "\n    %v: %v", k, s.Metadata[k])
+		}
+	}
+
+	if len(s.FieldErrors) > 0 {
+		b.WriteString("\n  field errors:")
+		for _, fieldError := range s.FieldErrors {
+			fmt.Fprintf(b, "\n    %v", fieldError)
+		}
+	}
+
+	var e *wrappedError
+	if errors.As(err, &e) {
+		b.WriteString("\n  stack:")
+		for _, frame := range filterStackTrace(FormatStackTrace(e.callers)) {
+			fmt.Fprintf(b, "\n    %v", frame)
+		}
+	}
+
+	return b.String()
+}
+
+// PrintLog prints the error to the given logger (or the standard logger if nil) using Format.
+func PrintLog(l *log.Logger, err error) {
+	if l == nil {
+		l = log.Default()
+	}
+	l.Print(Format(err))
+}
+
+// LogTest logs the error to the test using Format. It does nothing if the error is nil.
+func LogTest(t TB, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Log("\n" + Format(err))
+	}
+}
+
+// LogTestOnFailure logs the error to the test using Format, but only if the test fails.
+// It does nothing if the error is nil.
+func LogTestOnFailure(t TB, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Cleanup(func() {
+			if t.Failed() {
+				LogTest(t, err)
+			}
+		})
+	}
+}
+
+func filterStackTrace(stackTrace []string) []string {
+	filtered := make([]string, 0, len(stackTrace))
+
+frames:
+	for _, frame := range stackTrace {
+		for _, prefix := range filteredStackPrefixes {
+			if strings.HasPrefix(frame, prefix) {
+				continue frames
+			}
+		}
+		filtered = append(filtered, frame)
+	}
+
+	return filtered
+}
@@ -0,0 +1,110 @@
+package errorz_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type testTB struct {
+	failed   bool
+	logs     []string
+	cleanups []func()
+}
+
+// Helper implements the errorz.TB interface.
+func (*testTB) Helper() {
+	// intentionally empty
+}
+
+// Log implements the errorz.TB interface.
+func (t *testTB) Log(args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+// Failed implements the errorz.TB interface.
+func (t *testTB) Failed() bool {
+	return t.failed
+}
+
+// Cleanup implements the errorz.TB interface.
+func (t *testTB) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *testTB) runCleanups() {
+	for _, f := range t.cleanups {
+		f()
+	}
+}
+
+func TestFormat(t *testing.T) {
+	s := errorz.Format(errorz.Errorf("test error",
+		errorz.ID("id"),
+		errorz.Code(10),
+		errorz.Status(http.StatusNotFound),
+		errorz.M("k2", "v2"),
+		errorz.M("k1", 1),
+		errorz.FieldErrors{{Path: "a", Message: "bad"}}))
+
+	lines := strings.Split(s, "\n")
+	require.Equal(t, []string{
+		"test error",
+		"  id: id",
+		"  code: 10",
+		"  status: 404",
+		"  severity: warning",
+		"  metadata:",
+		"    k1: 1",
+		"    k2: v2",
+		"  field errors:",
+		"    a: bad",
+		"  stack:",
+	}, lines[:11])
+	require.True(t, strings.HasPrefix(lines[11], "    errorz_test.TestFormat "))
+	require.NotContains(t, s, "testing.tRunner")
+
+	require.Equal(t, "test error\n  severity: error", errorz.Format(fmt.Errorf("test error")))
+}
+
+func TestPrintLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	errorz.PrintLog(log.New(buf, "", 0), errorz.Errorf("test error"))
+	require.True(t, strings.HasPrefix(buf.String(), "test error\n  severity: error\n  stack:\n    errorz_test.TestPrintLog "))
+
+	buf.Reset()
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(buf)
+	errorz.PrintLog(nil, fmt.Errorf("test error"))
+	require.Contains(t, buf.String(), "test error\n  severity: error\n")
+}
+
+func TestLogTest(t *testing.T) {
+	tb := &testTB{}
+	errorz.LogTest(tb, nil)
+	require.Empty(t, tb.logs)
+	errorz.LogTest(tb, fmt.Errorf("test error"))
+	require.Equal(t, []string{"\ntest error\n  severity: error"}, tb.logs)
+}
+
+func TestLogTestOnFailure(t *testing.T) {
+	tb := &testTB{}
+	errorz.LogTestOnFailure(tb, nil)
+	errorz.LogTestOnFailure(tb, fmt.Errorf("test error"))
+	tb.runCleanups()
+	require.Empty(t, tb.logs)
+
+	tb = &testTB{failed: true}
+	errorz.LogTestOnFailure(tb, fmt.Errorf("test error"))
+	tb.runCleanups()
+	require.Equal(t, []string{"\ntest error\n  severity: error"}, tb.logs)
+
+	var _ errorz.TB = t
+}
@@ -0,0 +1,59 @@
+package errorz
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Header attaches an HTTP response header to the error, replacing any previous values for the same key.
+func Header(key string, values ...string) OptionFunc {
+	return func(err error) {
+		if e, ok := err.(*wrappedError); ok {
+			if e.headers == nil {
+				e.headers = http.Header{}
+			}
+			e.headers.Del(key)
+			for _, value := range values {
+				e.headers.Add(key, value)
+			}
+		}
+	}
+}
+
+// RetryAfter attaches a "Retry-After" HTTP response header to the error, rounding the duration up to the second.
+func RetryAfter(d time.Duration) OptionFunc {
+	if d < 0 {
+		d = 0
+	}
+	return Header("Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
+
+// GetHeaders gets the HTTP response headers from the error, merging the ones attached at every layer of the error
+// chain. When multiple layers define the same key, the outermost one wins. Returns an empty http.Header if none.
+func GetHeaders(err error) http.Header {
+	headers := http.Header{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*wrappedError); ok {
+			for k, v := range e.headers {
+				if _, ok := headers[k]; !ok {
+					headers[k] = append([]string(nil), v...)
+				}
+			}
+		}
+	}
+
+	return headers
+}
+
+// GetRetryAfter gets the duration from the "Retry-After" header attached to the error, if set in delay-seconds form.
+func GetRetryAfter(err error) (time.Duration, bool) {
+	seconds, convErr := strconv.ParseInt(GetHeaders(err).Get("Retry-After"), 10, 64)
+	if convErr != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,55 @@
+package errorz_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestHeaders(t *testing.T) {
+	require.Equal(t, http.Header{}, errorz.GetHeaders(nil))
+	require.Equal(t, http.Header{}, errorz.GetHeaders(fmt.Errorf("test error")))
+	require.Equal(t, http.Header{}, errorz.GetHeaders(errorz.Errorf("test error")))
+
+	err := errorz.Errorf("test error",
+		errorz.Header("WWW-Authenticate", `Bearer realm="example"`),
+		errorz.Header("x-multi", "a", "b"))
+	require.Equal(t, http.Header{
+		"Www-Authenticate": {`Bearer realm="example"`},
+		"X-Multi":          {"a", "b"},
+	}, errorz.GetHeaders(err))
+
+	err = errorz.Wrap(err, errorz.Header("X-Multi", "c"))
+	require.Equal(t, []string{"c"}, errorz.GetHeaders(err).Values("X-Multi"))
+
+	err = errorz.Wrap(fmt.Errorf("outer: %w", err), errorz.Header("X-Multi", "d"), errorz.Header("X-Outer", "e"))
+	require.Equal(t, http.Header{
+		"Www-Authenticate": {`Bearer realm="example"`},
+		"X-Multi":          {"d"},
+		"X-Outer":          {"e"},
+	}, errorz.GetHeaders(err))
+
+	errorz.GetHeaders(err).Set("X-Outer", "changed")
+	require.Equal(t, "e", errorz.GetHeaders(err).Get("X-Outer"))
+}
+
+func TestRetryAfter(t *testing.T) {
+	_, ok := errorz.GetRetryAfter(errorz.Errorf("test error"))
+	require.False(t, ok)
+	_, ok = errorz.GetRetryAfter(errorz.Errorf("test error", errorz.Header("Retry-After", "Wed, 21 Oct 2015 07:28:00 GMT")))
+	require.False(t, ok)
+
+	err := errorz.Errorf("test error", errorz.Status(http.StatusTooManyRequests), errorz.RetryAfter(1500*time.Millisecond))
+	require.Equal(t, "2", errorz.GetHeaders(err).Get("Retry-After"))
+	d, ok := errorz.GetRetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, d)
+
+	err = errorz.Errorf("test error", errorz.RetryAfter(-time.Second))
+	require.Equal(t, "0", errorz.GetHeaders(err).Get("Retry-After"))
+}
@@ -0,0 +1,78 @@
+package errorz
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	wrapHooks    = &hookList{}
+	recoverHooks = &hookList{}
+	mustHooks    = &hookList{}
+)
+
+// Hook is called with an error at specific points of its lifecycle (see OnWrap, OnRecover, OnMust).
+// Hooks are called synchronously on the goroutine creating the error, so they should be fast and must not panic.
+type Hook func(err error)
+
+// OnWrap registers a hook called each time a new error is wrapped (i.e. Wrap is called on an error not created by
+// this package), after the options have been applied. It returns a function which unregisters the hook.
+// Note: errors wrapped from within the hook trigger it again.
+func OnWrap(h Hook) func() {
+	return wrapHooks.add(h)
+}
+
+// OnRecover registers a hook called each time WrapRecover converts a recovered panic to an error.
+// It returns a function which unregisters the hook.
+func OnRecover(h Hook) func() {
+	return recoverHooks.add(h)
+}
+
+// OnMust registers a hook called each time MustWrap (or a variant, such as MustErrorf or Assertf) is about to panic.
+// It returns a function which unregisters the hook.
+func OnMust(h Hook) func() {
+	return mustHooks.add(h)
+}
+
+type hookList struct {
+	count int64
+	m     sync.RWMutex
+	hooks []*Hook
+}
+
+func (l *hookList) add(h Hook) func() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	p := &h
+	l.hooks = append(l.hooks, p)
+	atomic.AddInt64(&l.count, 1)
+
+	return func() {
+		l.m.Lock()
+		defer l.m.Unlock()
+
+		for i, other := range l.hooks {
+			if other == p {
+				l.hooks = append(l.hooks[:i:i], l.hooks[i+1:]...)
+				atomic.AddInt64(&l.count, -1)
+				return
+			}
+		}
+	}
+}
+
+// call calls the registered hooks in registration order. It only costs an atomic load if none is registered.
+func (l *hookList) call(err error) {
+	if atomic.LoadInt64(&l.count) == 0 {
+		return
+	}
+
+	l.m.RLock()
+	hooks := l.hooks
+	l.m.RUnlock()
+
+	for _, h := range hooks {
+		(*h)(err)
+	}
+}
@@ -0,0 +1,84 @@
+package errorz_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestOnWrap(t *testing.T) {
+	var wrapped []error
+	unregister := errorz.OnWrap(func(err error) { wrapped = append(wrapped, err) })
+
+	err := errorz.Wrap(fmt.Errorf("test error"), errorz.ID("id"))
+	require.Len(t, wrapped, 1)
+	require.Equal(t, err, wrapped[0])
+	require.Equal(t, errorz.ID("id"), errorz.GetID(wrapped[0]))
+
+	require.Equal(t, err, errorz.Wrap(err, errorz.Status(500)))
+	require.Len(t, wrapped, 1)
+
+	err = errorz.Errorf("test error")
+	require.Len(t, wrapped, 2)
+	require.Equal(t, err, wrapped[1])
+
+	unregister()
+	unregister()
+	errorz.Errorf("test error")
+	require.Len(t, wrapped, 2)
+}
+
+func TestOnRecover(t *testing.T) {
+	var recovered []error
+	defer errorz.OnRecover(func(err error) { recovered = append(recovered, err) })()
+	var wrapped []error
+	defer errorz.OnWrap(func(err error) { wrapped = append(wrapped, err) })()
+
+	err := errorz.WrapRecover("test panic")
+	require.Equal(t, []error{err}, recovered)
+	require.Equal(t, []error{err}, wrapped)
+
+	err = errorz.Safe(func() error { panic(err) })()
+	require.Equal(t, []error{err, err}, recovered)
+	require.Len(t, wrapped, 1)
+
+	require.NoError(t, errorz.MaybeWrapRecover(nil))
+	require.Len(t, recovered, 2)
+}
+
+func TestOnMust(t *testing.T) {
+	var must []error
+	defer errorz.OnMust(func(err error) { must = append(must, err) })()
+
+	require.Panics(t, func() { errorz.MustWrap(fmt.Errorf("test error"), errorz.ID("id")) })
+	require.Len(t, must, 1)
+	require.Equal(t, errorz.ID("id"), errorz.GetID(must[0]))
+
+	require.Panics(t, func() { errorz.MustErrorf("test error") })
+	require.Panics(t, func() { errorz.Assertf(false, "test error") })
+	errorz.Assertf(true, "test error")
+	errorz.MaybeMustWrap(nil)
+	require.Len(t, must, 3)
+}
+
+func TestHooksConcurrency(t *testing.T) {
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				unregister := errorz.OnWrap(func(error) {})
+				_ = errorz.Errorf("test error")
+				unregister()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,156 @@
+// Package httpz provides net/http integration for errorz errors.
+package httpz
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ http.Handler = HandlerFunc(nil)
+)
+
+// DefaultConfig is the Config used by the package-level helpers.
+var DefaultConfig = &Config{}
+
+// Config describes how errors are rendered to HTTP responses.
+type Config struct {
+	// Debug includes stack traces in response bodies. It should not be enabled in production.
+	Debug bool
+	// Problem, if set, renders errors as RFC 9457 problem details documents instead of JSON errorz.Summary.
+	Problem *ProblemConfig
+	// OnError is called with every error before it is rendered to a response (e.g. for logging).
+	OnError func(r *http.Request, err error)
+	// OnPanic is called with every recovered panic, converted to error, before OnError.
+	OnPanic func(r *http.Request, err error)
+}
+
+// HandlerFunc describes an HTTP handler which can return an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements the http.Handler interface using DefaultConfig.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	DefaultConfig.Handler(f).ServeHTTP(w, r)
+}
+
+// Middleware is like Config.Middleware using DefaultConfig.
+func Middleware(next http.Handler) http.Handler {
+	return DefaultConfig.Middleware(next)
+}
+
+// WriteError is like Config.WriteError using DefaultConfig.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultConfig.WriteError(w, r, err)
+}
+
+// Middleware returns a middleware which recovers panics in the next handler and renders them as errors.
+// Panics with http.ErrAbortHandler are propagated, as they are used to abort responses.
+func (c *Config) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			err := errorz.WrapRecover(rec, errorz.Skip())
+			if c.OnPanic != nil {
+				c.OnPanic(r, err)
+			}
+			c.writeError(rw, r, err)
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// Handler converts a HandlerFunc to an http.Handler, which renders returned errors and recovers panics.
+func (c *Config) Handler(f HandlerFunc) http.Handler {
+	return c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := f(w, r); err != nil {
+			c.WriteError(w, r, errorz.Wrap(err, errorz.Skip()))
+		}
+	}))
+}
+
+// WriteError renders the error to the response as JSON errorz.Summary (or as problem details document, see
+// Config.Problem), using its status (500 if not set) and headers.
+// Nothing is written if the response headers have already been sent by a handler wrapped by Middleware.
+func (c *Config) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	c.writeError(w, r, err)
+}
+
+func (c *Config) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if c.OnError != nil {
+		c.OnError(r, err)
+	}
+
+	if rw, ok := w.(*responseWriter); ok && rw.wroteHeader {
+		return
+	}
+
+	if c.Problem != nil {
+		p := c.Problem.ToProblem(r, err)
+		if c.Debug {
+			p.Extensions["stackTrace"] = errorz.FormatStackTrace(errorz.GetCallers(err))
+		}
+		c.Problem.writeProblem(w, err, p)
+		return
+	}
+
+	summary := errorz.ToSummary(err)
+	if !c.Debug {
+		summary.StackTrace = nil
+	}
+
+	status := errorz.GetStatus(err).Int()
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	for k, v := range errorz.GetHeaders(err) {
+		w.Header()[k] = v
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(summary)
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (w *responseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *responseWriter) Write(buf []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(buf)
+}
+
+// Flush implements the http.Flusher interface.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use with http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
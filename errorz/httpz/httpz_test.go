@@ -0,0 +1,120 @@
+package httpz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/httpz"
+)
+
+func serve(h http.Handler) (*httptest.ResponseRecorder, *errorz.Summary) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		return w, nil
+	}
+
+	s := &errorz.Summary{}
+	errorz.MaybeMustWrap(json.Unmarshal(w.Body.Bytes(), s))
+	return w, s
+}
+
+func TestHandlerFunc(t *testing.T) {
+	w, s := serve(httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		_, err := w.Write([]byte("ok"))
+		return err
+	}))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "ok", w.Body.String())
+	require.Nil(t, s)
+
+	w, s = serve(httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errorz.Errorf("not found", errorz.ID("not-found"), errorz.Status(http.StatusNotFound), errorz.M("k", "v"))
+	}))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, errorz.ID("not-found"), s.ID)
+	require.Equal(t, errorz.Status(http.StatusNotFound), s.Status)
+	require.Equal(t, "not found", s.Message)
+	require.Equal(t, map[string]interface{}{"k": "v"}, s.Metadata)
+	require.Empty(t, s.StackTrace)
+
+	w, s = serve(httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("plain error")
+	}))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "plain error", s.Message)
+
+	w, s = serve(httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errorz.Errorf("too many requests", errorz.Status(http.StatusTooManyRequests), errorz.RetryAfter(time.Minute))
+	}))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "60", w.Header().Get("Retry-After"))
+	require.Equal(t, "too many requests", s.Message)
+
+	w, s = serve(httpz.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusAccepted)
+		return errorz.Errorf("late error")
+	}))
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Nil(t, s)
+}
+
+func TestMiddleware(t *testing.T) {
+	w, s := serve(httpz.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(errorz.Errorf("panic error", errorz.Status(http.StatusBadRequest)))
+	})))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "panic error", s.Message)
+
+	w, s = serve(httpz.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("string panic")
+	})))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "string panic", s.Message)
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		serve(httpz.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		})))
+	})
+}
+
+func TestConfig(t *testing.T) {
+	var panics, errs []error
+
+	c := &httpz.Config{
+		Debug:   true,
+		OnError: func(_ *http.Request, err error) { errs = append(errs, err) },
+		OnPanic: func(_ *http.Request, err error) { panics = append(panics, err) },
+	}
+
+	w, s := serve(c.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errorz.Errorf("returned error")
+	}))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotEmpty(t, s.StackTrace)
+	require.Len(t, errs, 1)
+	require.Len(t, panics, 0)
+
+	w, s = serve(c.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		panic("panic error")
+	}))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotEmpty(t, s.StackTrace)
+	require.Len(t, errs, 2)
+	require.Len(t, panics, 1)
+	require.EqualError(t, panics[0], "panic error")
+
+	w = httptest.NewRecorder()
+	httpz.WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), errorz.Errorf("direct error", errorz.Status(http.StatusConflict)))
+	require.Equal(t, http.StatusConflict, w.Code)
+}
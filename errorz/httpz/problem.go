@@ -0,0 +1,259 @@
+package httpz
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+// ProblemContentType is the content type of RFC 9457 problem details documents.
+const ProblemContentType = "application/problem+json"
+
+var (
+	_ json.Marshaler   = &Problem{}
+	_ json.Unmarshaler = &Problem{}
+)
+
+var (
+	problemMembers = map[string]struct{}{
+		"type":     {},
+		"title":    {},
+		"status":   {},
+		"detail":   {},
+		"instance": {},
+	}
+
+	problemExtensions = map[string]struct{}{
+		"id":          {},
+		"fieldErrors": {},
+		"metadata":    {},
+	}
+)
+
+// DefaultProblemConfig is the ProblemConfig used by the package-level helpers.
+var DefaultProblemConfig = &ProblemConfig{}
+
+// Problem describes an RFC 9457 problem details document.
+// Extension members are serialized at the top level, alongside the standard members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+
+	for k, v := range p.Extensions {
+		if _, ok := problemMembers[k]; !ok {
+			m[k] = v
+		}
+	}
+
+	setIfNotEmpty(m, "type", p.Type)
+	setIfNotEmpty(m, "title", p.Title)
+	setIfNotEmpty(m, "detail", p.Detail)
+	setIfNotEmpty(m, "instance", p.Instance)
+
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Standard members of the wrong type are ignored, as mandated by the RFC.
+func (p *Problem) UnmarshalJSON(buf []byte) error {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return errorz.Wrap(err)
+	}
+
+	*p = Problem{}
+	p.Type, _ = m["type"].(string)
+	p.Title, _ = m["title"].(string)
+	p.Detail, _ = m["detail"].(string)
+	p.Instance, _ = m["instance"].(string)
+
+	if status, ok := m["status"].(float64); ok {
+		p.Status = int(status)
+	}
+
+	for k, v := range m {
+		if _, ok := problemMembers[k]; !ok {
+			if p.Extensions == nil {
+				p.Extensions = map[string]interface{}{}
+			}
+			p.Extensions[k] = v
+		}
+	}
+
+	return nil
+}
+
+// ProblemConfig describes how errors are converted to and from problem details documents.
+type ProblemConfig struct {
+	// TypeBaseURL is used to build the "type" member as TypeBaseURL + ID. If empty, or if the error has no id, the
+	// "type" member is omitted (i.e. it defaults to "about:blank").
+	TypeBaseURL string
+	// Titles maps error ids to "title" members. Errors with unknown or no id use the text of their status.
+	Titles map[errorz.ID]string
+}
+
+// ToProblem is like ProblemConfig.ToProblem using DefaultProblemConfig.
+func ToProblem(r *http.Request, err error) *Problem {
+	return DefaultProblemConfig.ToProblem(r, err)
+}
+
+// FromProblem is like ProblemConfig.FromProblem using DefaultProblemConfig.
+func FromProblem(p *Problem) error {
+	return DefaultProblemConfig.FromProblem(p)
+}
+
+// ToProblem converts an error to a problem details document. The request (optional) is used for the "instance"
+// member. The error id, field errors and metadata are exposed as "id", "fieldErrors" and "metadata" extension members.
+// Metadata is namespaced so that its keys can't clash with standard or extension members.
+func (c *ProblemConfig) ToProblem(r *http.Request, err error) *Problem {
+	id := errorz.GetID(err)
+
+	status := errorz.GetStatus(err).Int()
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	p := &Problem{
+		Title:      c.Titles[id],
+		Status:     status,
+		Detail:     err.Error(),
+		Extensions: map[string]interface{}{},
+	}
+
+	if p.Title == "" {
+		p.Title = http.StatusText(status)
+	}
+
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.RequestURI()
+	}
+
+	if metadata := errorz.GetMetadata(err); len(metadata) > 0 {
+		m := make(map[string]interface{}, len(metadata))
+		for k, v := range metadata {
+			m[k] = v
+		}
+		p.Extensions["metadata"] = m
+	}
+
+	if fieldErrors := errorz.GetFieldErrors(err); len(fieldErrors) > 0 {
+		p.Extensions["fieldErrors"] = fieldErrors
+	}
+
+	if id != "" {
+		p.Extensions["id"] = id.String()
+
+		if c.TypeBaseURL != "" {
+			p.Type = c.TypeBaseURL + id.String()
+		}
+	}
+
+	return p
+}
+
+// FromProblem converts a problem details document to an error, restoring id, status, field errors and metadata. The id
+// is taken from the "id" extension member or, if missing, from a "type" member starting with TypeBaseURL. Metadata is
+// taken from the "metadata" extension member. Other extension members (e.g. from problem details documents not
+// produced by ToProblem), as well as the "instance" member (under the "problem-instance" key), are also restored as
+// metadata, unless they clash with keys of the "metadata" member.
+func (c *ProblemConfig) FromProblem(p *Problem) error {
+	message := p.Detail
+	if message == "" {
+		message = p.Title
+	}
+	if message == "" {
+		message = http.StatusText(p.Status)
+	}
+
+	metadata := errorz.Metadata{}
+	for k, v := range p.Extensions {
+		if _, ok := problemExtensions[k]; !ok {
+			metadata[k] = v
+		}
+	}
+
+	if p.Instance != "" {
+		metadata["problem-instance"] = p.Instance
+	}
+
+	if raw, ok := p.Extensions["metadata"]; ok {
+		if m, ok := raw.(map[string]interface{}); ok {
+			for k, v := range m {
+				metadata[k] = v
+			}
+		} else {
+			metadata["metadata"] = raw
+		}
+	}
+
+	id, _ := p.Extensions["id"].(string)
+	if id == "" && c.TypeBaseURL != "" && strings.HasPrefix(p.Type, c.TypeBaseURL) {
+		id = strings.TrimPrefix(p.Type, c.TypeBaseURL)
+	}
+
+	fieldErrors := errorz.FieldErrors{}
+	if raw, ok := p.Extensions["fieldErrors"]; ok {
+		if buf, err := json.Marshal(raw); err != nil || json.Unmarshal(buf, &fieldErrors) != nil {
+			metadata["fieldErrors"] = raw
+		}
+	}
+
+	return errorz.Wrap(errors.New(message),
+		errorz.ID(id),
+		errorz.Status(p.Status),
+		fieldErrors,
+		metadata,
+		errorz.Skip())
+}
+
+// WriteProblem is like ProblemConfig.WriteProblem using DefaultProblemConfig.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultProblemConfig.WriteProblem(w, r, err)
+}
+
+// WriteProblem renders the error to the response as problem details document, including the error headers.
+func (c *ProblemConfig) WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	c.writeProblem(w, err, c.ToProblem(r, err))
+}
+
+func (c *ProblemConfig) writeProblem(w http.ResponseWriter, err error, p *Problem) {
+	for k, v := range errorz.GetHeaders(err) {
+		w.Header()[k] = v
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// ParseProblem parses a problem details document.
+func ParseProblem(buf []byte) (*Problem, error) {
+	p := &Problem{}
+	if err := json.Unmarshal(buf, p); err != nil {
+		return nil, errorz.Wrap(err, errorz.Skip())
+	}
+	return p, nil
+}
+
+func setIfNotEmpty(m map[string]interface{}, k, v string) {
+	if v != "" {
+		m[k] = v
+	}
+}
@@ -0,0 +1,206 @@
+package httpz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/httpz"
+)
+
+func TestProblemJSON(t *testing.T) {
+	buf, err := json.Marshal(&httpz.Problem{
+		Type:       "https://example.com/errors/not-found",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "user not found",
+		Instance:   "/users/1",
+		Extensions: map[string]interface{}{"k": "v", "status": "ignored"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "https://example.com/errors/not-found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user not found",
+		"instance": "/users/1",
+		"k": "v"
+	}`, string(buf))
+
+	p, err := httpz.ParseProblem(buf)
+	require.NoError(t, err)
+	require.Equal(t, &httpz.Problem{
+		Type:       "https://example.com/errors/not-found",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "user not found",
+		Instance:   "/users/1",
+		Extensions: map[string]interface{}{"k": "v"},
+	}, p)
+
+	buf, err = json.Marshal(&httpz.Problem{})
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(buf))
+
+	p, err = httpz.ParseProblem([]byte(`{"status": "bad", "title": 10}`))
+	require.NoError(t, err)
+	require.Equal(t, &httpz.Problem{}, p)
+
+	_, err = httpz.ParseProblem([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestToProblem(t *testing.T) {
+	c := &httpz.ProblemConfig{
+		TypeBaseURL: "https://example.com/errors/",
+		Titles:      map[errorz.ID]string{"user-not-found": "User Not Found"},
+	}
+
+	p := c.ToProblem(
+		httptest.NewRequest(http.MethodGet, "/users/1?x=y", nil),
+		errorz.Errorf("user not found", errorz.ID("user-not-found"), errorz.Status(http.StatusNotFound), errorz.M("k", "v")))
+	require.Equal(t, &httpz.Problem{
+		Type:       "https://example.com/errors/user-not-found",
+		Title:      "User Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "user not found",
+		Instance:   "/users/1?x=y",
+		Extensions: map[string]interface{}{"id": "user-not-found", "metadata": map[string]interface{}{"k": "v"}},
+	}, p)
+
+	p = httpz.ToProblem(nil, fmt.Errorf("test error"))
+	require.Equal(t, &httpz.Problem{
+		Title:      "Internal Server Error",
+		Status:     http.StatusInternalServerError,
+		Detail:     "test error",
+		Extensions: map[string]interface{}{},
+	}, p)
+}
+
+func TestFromProblem(t *testing.T) {
+	c := &httpz.ProblemConfig{TypeBaseURL: "https://example.com/errors/"}
+
+	err := c.FromProblem(&httpz.Problem{
+		Type:       "https://example.com/errors/user-not-found",
+		Status:     http.StatusNotFound,
+		Detail:     "user not found",
+		Instance:   "/users/1",
+		Extensions: map[string]interface{}{"k": "v"},
+	})
+	require.EqualError(t, err, "user not found")
+	require.Equal(t, errorz.ID("user-not-found"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusNotFound), errorz.GetStatus(err))
+	require.Equal(t, errorz.Metadata{"k": "v", "problem-instance": "/users/1"}, errorz.GetMetadata(err))
+
+	err = httpz.FromProblem(&httpz.Problem{
+		Type:       "https://other.com/user-not-found",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Extensions: map[string]interface{}{"id": "remote-id"},
+	})
+	require.EqualError(t, err, "Not Found")
+	require.Equal(t, errorz.ID("remote-id"), errorz.GetID(err))
+	require.Equal(t, errorz.Metadata{}, errorz.GetMetadata(err))
+
+	err = httpz.FromProblem(&httpz.Problem{
+		Status: http.StatusConflict,
+		Extensions: map[string]interface{}{
+			"k1":          "top-level",
+			"k2":          "top-level",
+			"metadata":    map[string]interface{}{"k2": "namespaced", "id": "metadata-id"},
+			"fieldErrors": "invalid",
+		},
+	})
+	require.Equal(t, errorz.ID(""), errorz.GetID(err))
+	require.Equal(t, errorz.Metadata{"k1": "top-level", "k2": "namespaced", "id": "metadata-id", "fieldErrors": "invalid"}, errorz.GetMetadata(err))
+
+	err = httpz.FromProblem(&httpz.Problem{Status: http.StatusConflict, Extensions: map[string]interface{}{"metadata": "invalid"}})
+	require.Equal(t, errorz.Metadata{"metadata": "invalid"}, errorz.GetMetadata(err))
+
+	err = httpz.FromProblem(&httpz.Problem{Status: http.StatusConflict})
+	require.EqualError(t, err, "Conflict")
+	require.Equal(t, errorz.ID(""), errorz.GetID(err))
+}
+
+func TestProblemRoundTrip(t *testing.T) {
+	w := httptest.NewRecorder()
+	httpz.WriteProblem(w, httptest.NewRequest(http.MethodGet, "/x", nil),
+		errorz.Errorf("conflict", errorz.ID("conflict-id"), errorz.Status(http.StatusConflict), errorz.Header("X-K", "v")))
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, httpz.ProblemContentType, w.Header().Get("Content-Type"))
+	require.Equal(t, "v", w.Header().Get("X-K"))
+
+	p, err := httpz.ParseProblem(w.Body.Bytes())
+	require.NoError(t, err)
+	err = httpz.FromProblem(p)
+	require.EqualError(t, err, "conflict")
+	require.Equal(t, errorz.ID("conflict-id"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusConflict), errorz.GetStatus(err))
+}
+
+func TestProblemReservedMetadataKeys(t *testing.T) {
+	metadata := errorz.Metadata{
+		"id":          "metadata-id",
+		"type":        "metadata-type",
+		"title":       "metadata-title",
+		"status":      "metadata-status",
+		"detail":      "metadata-detail",
+		"instance":    "metadata-instance",
+		"fieldErrors": "metadata-field-errors",
+		"metadata":    "metadata-metadata",
+	}
+
+	w := httptest.NewRecorder()
+	httpz.WriteProblem(w, nil, errorz.Errorf("conflict", errorz.ID("conflict-id"), errorz.Status(http.StatusConflict), metadata))
+
+	p, err := httpz.ParseProblem(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "conflict", p.Detail)
+	require.Equal(t, http.StatusConflict, p.Status)
+
+	err = httpz.FromProblem(p)
+	require.Equal(t, errorz.ID("conflict-id"), errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusConflict), errorz.GetStatus(err))
+	require.Equal(t, metadata, errorz.GetMetadata(err))
+}
+
+func TestConfigProblem(t *testing.T) {
+	c := &httpz.Config{Debug: true, Problem: &httpz.ProblemConfig{}}
+	w := httptest.NewRecorder()
+	c.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errorz.Errorf("test error", errorz.Status(http.StatusBadRequest))
+	}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, httpz.ProblemContentType, w.Header().Get("Content-Type"))
+
+	p, err := httpz.ParseProblem(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "test error", p.Detail)
+	require.NotEmpty(t, p.Extensions["stackTrace"])
+}
+
+func TestProblemFieldErrors(t *testing.T) {
+	err := errorz.NewValidation().Add("name", "required", "is required").Err()
+
+	p := httpz.ToProblem(nil, err)
+	require.Equal(t, errorz.FieldErrors{{Path: "name", Rule: "required", Message: "is required"}}, p.Extensions["fieldErrors"])
+
+	buf, jsonErr := json.Marshal(p)
+	require.NoError(t, jsonErr)
+	p, jsonErr = httpz.ParseProblem(buf)
+	require.NoError(t, jsonErr)
+
+	err = httpz.FromProblem(p)
+	require.Equal(t, errorz.InvalidArgumentID, errorz.GetID(err))
+	require.Equal(t, errorz.FieldErrors{{Path: "name", Rule: "required", Message: "is required"}}, errorz.GetFieldErrors(err))
+	require.Equal(t, errorz.Metadata{}, errorz.GetMetadata(err))
+
+	err = httpz.FromProblem(&httpz.Problem{Status: http.StatusBadRequest, Extensions: map[string]interface{}{"fieldErrors": "bad"}})
+	require.Nil(t, errorz.GetFieldErrors(err))
+	require.Equal(t, errorz.Metadata{"fieldErrors": "bad"}, errorz.GetMetadata(err))
+}
@@ -0,0 +1,197 @@
+package httpz
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ http.RoundTripper = &Transport{}
+)
+
+const (
+	// DefaultMaxBodyBytes is the default maximum number of bytes read from error responses.
+	DefaultMaxBodyBytes = 64 * 1024
+	// DefaultMaxExcerptBytes is the default maximum number of bytes of error response bodies attached as metadata.
+	DefaultMaxExcerptBytes = 512
+)
+
+// DefaultResponseHeaders lists the response headers carried over by default to errors returned by CheckResponse.
+var DefaultResponseHeaders = []string{"Retry-After"}
+
+// Transport is an http.RoundTripper which wraps transport errors with method, URL (with secrets redacted) and timing
+// metadata. Responses, including error responses, are returned unchanged: callers must call CheckResponse on them, or
+// error responses are not converted to errors. Note that http.Client wraps errors returned by RoundTrip in *url.Error,
+// use errors.Unwrap to get the errorz error.
+type Transport struct {
+	// Base is the underlying http.RoundTripper, defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Problem is used to decode problem details responses, defaults to DefaultProblemConfig.
+	Problem *ProblemConfig
+	// MaxBodyBytes is the maximum number of bytes read from error responses, defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// MaxExcerptBytes is the maximum number of bytes of the body attached as metadata, defaults to
+	// DefaultMaxExcerptBytes.
+	MaxExcerptBytes int
+	// RedactedQueryParams lists query parameters redacted from URLs, defaults to errorz.DefaultRedactedQueryParams.
+	RedactedQueryParams []string
+	// ResponseHeaders lists the response headers carried over to errors, defaults to DefaultResponseHeaders.
+	ResponseHeaders []string
+}
+
+// NewClient returns an http.Client using a Transport with default settings.
+func NewClient() *http.Client {
+	return &http.Client{Transport: &Transport{}}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.getBase().RoundTrip(req)
+
+	if err != nil {
+		return nil, errorz.Wrap(err, errorz.Metadata{
+			"http-method":   req.Method,
+			"http-url":      t.redactURL(req.URL),
+			"http-duration": time.Since(start),
+		})
+	}
+
+	return resp, nil
+}
+
+// CheckResponse is like Transport.CheckResponse using a Transport with default settings.
+func CheckResponse(resp *http.Response) error {
+	return (&Transport{}).CheckResponse(resp)
+}
+
+// CheckResponse converts error responses (status >= 400) to errorz errors, returning nil for other responses:
+//   - responses with an errorz.Summary JSON or problem details body are decoded to an error which keeps the remote id
+//     and status;
+//   - other responses are converted to an error with the response status and an excerpt of the body.
+//
+// Errors have method, URL (with secrets redacted) and status metadata, and carry over the ResponseHeaders. They have no
+// timing metadata, as CheckResponse runs after the round trip and may be given responses from any http.RoundTripper.
+// Up to MaxBodyBytes of the body are read, but the body is left readable from the start: the caller must still close
+// it.
+func (t *Transport) CheckResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	metadata := errorz.Metadata{
+		"http-status": resp.StatusCode,
+	}
+
+	if resp.Request != nil {
+		metadata["http-method"] = resp.Request.Method
+		metadata["http-url"] = t.redactURL(resp.Request.URL)
+	}
+
+	buf, readErr := io.ReadAll(io.LimitReader(resp.Body, t.getMaxBodyBytes()))
+	resp.Body = &restoredBody{Reader: io.MultiReader(bytes.NewReader(buf), resp.Body), Closer: resp.Body}
+
+	if readErr != nil {
+		return errorz.Wrap(readErr, metadata, errorz.SkipPackage())
+	}
+
+	err := t.decodeError(resp, buf)
+	if err == nil {
+		metadata["http-body"] = t.getExcerpt(buf)
+
+		err = errorz.Errorf("http: unexpected response status: %v",
+			errorz.A(resp.Status),
+			errorz.Status(resp.StatusCode))
+	}
+
+	for _, k := range t.getResponseHeaders() {
+		if values := resp.Header.Values(k); len(values) > 0 {
+			errorz.Header(k, values...).Apply(err)
+		}
+	}
+
+	return errorz.Wrap(err, metadata, errorz.SkipPackage())
+}
+
+type restoredBody struct {
+	io.Reader
+	io.Closer
+}
+
+func (t *Transport) decodeError(resp *http.Response, buf []byte) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case ProblemContentType:
+		if p, err := ParseProblem(buf); err == nil {
+			if p.Status == 0 {
+				p.Status = resp.StatusCode
+			}
+			return t.getProblem().FromProblem(p)
+		}
+	case "application/json":
+		s := &errorz.Summary{}
+		if err := json.Unmarshal(buf, s); err == nil && s.Message != "" {
+			if s.Status == 0 {
+				s.Status = errorz.Status(resp.StatusCode)
+			}
+			return errorz.Wrap(errors.New(s.Message),
+				s.ID, s.Code, s.Status, s.Severity, s.FieldErrors, errorz.Metadata(s.Metadata))
+		}
+	}
+
+	return nil
+}
+
+func (t *Transport) redactURL(u *url.URL) string {
+	return errorz.RedactURL(u, t.RedactedQueryParams)
+}
+
+func (t *Transport) getExcerpt(buf []byte) string {
+	maxExcerptBytes := t.MaxExcerptBytes
+	if maxExcerptBytes <= 0 {
+		maxExcerptBytes = DefaultMaxExcerptBytes
+	}
+
+	if len(buf) > maxExcerptBytes {
+		return string(buf[:maxExcerptBytes]) + "..."
+	}
+
+	return string(buf)
+}
+
+func (t *Transport) getBase() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) getProblem() *ProblemConfig {
+	if t.Problem != nil {
+		return t.Problem
+	}
+	return DefaultProblemConfig
+}
+
+func (t *Transport) getResponseHeaders() []string {
+	if t.ResponseHeaders != nil {
+		return t.ResponseHeaders
+	}
+	return DefaultResponseHeaders
+}
+
+func (t *Transport) getMaxBodyBytes() int64 {
+	if t.MaxBodyBytes > 0 {
+		return t.MaxBodyBytes
+	}
+	return DefaultMaxBodyBytes
+}
@@ -1,10 +1,17 @@
 package errorz
 
+import (
+	"strings"
+)
+
 var (
 	_ Option = ID("")
 )
 
-// ID describes an error id.
+// IDSeparators lists the characters that separate namespace segments within an error id.
+const IDSeparators = "./"
+
+// ID describes an error id. IDs can be namespaced using "." or "/" as separators (e.g. "db.conn.timeout").
 type ID string
 
 // String implements the fmt.Stringer interface.
@@ -12,6 +19,30 @@ func (id ID) String() string {
 	return string(id)
 }
 
+// Parent returns the parent namespace of the id (e.g. "db.conn" for "db.conn.timeout"), or an empty id if none.
+func (id ID) Parent() ID {
+	if i := strings.LastIndexAny(string(id), IDSeparators); i >= 0 {
+		return id[:i]
+	}
+	return ""
+}
+
+// HasPrefix returns true if the id is equal to or falls within the given namespace.
+// A prefix ending with a separator (e.g. "db.") matches any id that starts with it, otherwise the prefix must match
+// the id exactly or up to a separator (i.e. "db" matches "db" and "db.conn", but not "dbx").
+func (id ID) HasPrefix(prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	if strings.ContainsAny(prefix[len(prefix)-1:], IDSeparators) {
+		return strings.HasPrefix(string(id), prefix)
+	}
+	if !strings.HasPrefix(string(id), prefix) {
+		return false
+	}
+	return len(id) == len(prefix) || strings.ContainsAny(string(id[len(prefix)]), IDSeparators)
+}
+
 // Apply implements the Option interface.
 func (id ID) Apply(err error) {
 	if e, ok := err.(*wrappedError); ok {
@@ -19,10 +50,27 @@ func (id ID) Apply(err error) {
 	}
 }
 
-// GetID gets the id from the error, or an empty id if not set.
+// GetID gets the id from the error, falling back to the id registered for its code, then to its classification (see
+// GetClassification), or an empty id if not set.
 func GetID(err error) ID {
 	if e, ok := err.(*wrappedError); ok {
-		return e.id
+		if e.id != "" {
+			return e.id
+		}
+		if id, ok := GetIDForCode(e.code); ok {
+			return id
+		}
+	}
+
+	if c, ok := GetClassification(err); ok {
+		return c.ID
 	}
+
 	return ""
 }
+
+// HasIDPrefix returns true if the error has an id which falls within the given namespace (see ID.HasPrefix).
+func HasIDPrefix(err error, prefix string) bool {
+	id := GetID(err)
+	return id != "" && id.HasPrefix(prefix)
+}
@@ -0,0 +1,58 @@
+package errorz
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+)
+
+var (
+	_ slog.LogValuer = &wrappedError{}
+)
+
+// LogValue implements the slog.LogValuer interface, see LogValue.
+func (e *wrappedError) LogValue() slog.Value {
+	return LogValue(e, false)
+}
+
+// LogValue converts the error to a slog group value with the following attributes (omitted if empty): "message",
+// "id", "code", "status", "severity", "metadata" (as nested group), and "stack" if requested. The stack trace is only
+// included if an errorz error is found in the error chain.
+func LogValue(err error, withStack bool) slog.Value {
+	attrs := []slog.Attr{slog.String("message", err.Error())}
+
+	if id := GetID(err); id != "" {
+		attrs = append(attrs, slog.String("id", id.String()))
+	}
+
+	if code := GetCode(err); code != 0 {
+		attrs = append(attrs, slog.Int("code", code.Int()))
+	}
+
+	if status := GetStatus(err); status != 0 {
+		attrs = append(attrs, slog.Int("status", status.Int()))
+	}
+
+	attrs = append(attrs, slog.String("severity", GetSeverity(err).String()))
+
+	if metadata := GetMetadata(err); len(metadata) > 0 {
+		keys := make([]string, 0, len(metadata))
+		for k := range metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		metadataAttrs := make([]any, 0, len(keys))
+		for _, k := range keys {
+			metadataAttrs = append(metadataAttrs, slog.Any(k, metadata[k]))
+		}
+		attrs = append(attrs, slog.Group("metadata", metadataAttrs...))
+	}
+
+	var e *wrappedError
+	if withStack && errors.As(err, &e) {
+		attrs = append(attrs, slog.Any("stack", FormatStackTrace(e.callers)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
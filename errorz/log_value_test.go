@@ -0,0 +1,60 @@
+package errorz_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestLogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+
+	logger.Info("msg", "err", errorz.Errorf("test error",
+		errorz.ID("id"),
+		errorz.Code(10),
+		errorz.Status(http.StatusNotFound),
+		errorz.M("k2", "v2"),
+		errorz.M("k1", 1)))
+	require.JSONEq(t, `{
+		"level": "INFO",
+		"msg": "msg",
+		"err": {
+			"message": "test error",
+			"id": "id",
+			"code": 10,
+			"status": 404,
+			"severity": "warning",
+			"metadata": {"k1": 1, "k2": "v2"}
+		}
+	}`, buf.String())
+
+	v := errorz.LogValue(fmt.Errorf("test error"), true)
+	require.Equal(t, slog.KindGroup, v.Kind())
+	require.Equal(t, []slog.Attr{
+		slog.String("message", "test error"),
+		slog.String("severity", "error"),
+	}, v.Group())
+
+	v = errorz.LogValue(fmt.Errorf("wrapped: %w", errorz.Errorf("test error")), true)
+	attrs := v.Group()
+	require.Equal(t, "stack", attrs[len(attrs)-1].Key)
+	stack, ok := attrs[len(attrs)-1].Value.Any().([]string)
+	require.True(t, ok)
+	require.Contains(t, stack[0], "errorz_test.TestLogValue")
+
+	_, err := json.Marshal(attrs)
+	require.NoError(t, err)
+}
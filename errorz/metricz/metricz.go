@@ -0,0 +1,246 @@
+// Package metricz provides counters of errors by id, status and severity, published through expvar and the
+// Prometheus text exposition format. For example:
+//
+//	c := metricz.NewCollector(nil)
+//	errorz.SetReporter(errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{c}}))
+//	expvar.Publish("errors", c)
+//	http.Handle("/metrics", c.Handler())
+package metricz
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ errorz.Sink = &Collector{}
+	_ expvar.Var  = &Collector{}
+)
+
+const (
+	// NoneID is the id label of errors without id.
+	NoneID = "none"
+	// NoneSeverity is the severity label of errors without a known severity.
+	NoneSeverity = "none"
+	// OtherID is the id label of errors whose id is not tracked (see Config).
+	OtherID = "other"
+	// ContentType is the content type of the Prometheus text exposition format.
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Config describes the configuration of a Collector.
+type Config struct {
+	// IDs, if set, lists the ids tracked individually. Errors with ids in their sub-namespaces (see
+	// errorz.ID.HasPrefix) are counted under the most specific tracked id, so that the number of labels stays bounded.
+	// If not set, ids are tracked as they are seen, up to MaxIDs. Errors with untracked ids are counted as OtherID.
+	IDs []errorz.ID
+	// MaxIDs is the maximum number of ids tracked individually when IDs is not set (default 100).
+	MaxIDs int
+	// Name is the name of the Prometheus metric (default "errorz_errors_total").
+	Name string
+}
+
+// Sample describes the number of errors with a given id, status and severity.
+type Sample struct {
+	ID       string          `json:"id"`
+	Status   errorz.Status   `json:"status"`
+	Severity errorz.Severity `json:"severity"`
+	Count    int64           `json:"count"`
+}
+
+// Collector counts errors by id, status and severity, bounding the number of distinct ids (see Config). Statuses
+// outside the range of valid HTTP statuses, and unknown severities, are counted as 0. It is safe for concurrent use,
+// implements errorz.Sink so that it can receive errors from an errorz.Reporter, and expvar.Var so that it can be
+// published using expvar.
+type Collector struct {
+	cfg    Config
+	m      sync.RWMutex
+	ids    map[string]struct{}
+	counts map[sampleKey]int64
+}
+
+type sampleKey struct {
+	id       string
+	status   errorz.Status
+	severity errorz.Severity
+}
+
+// NewCollector initializes a new Collector.
+func NewCollector(cfg *Config) *Collector {
+	c := &Collector{
+		ids:    map[string]struct{}{},
+		counts: map[sampleKey]int64{},
+	}
+
+	if cfg != nil {
+		c.cfg = *cfg
+	}
+	if c.cfg.MaxIDs <= 0 {
+		c.cfg.MaxIDs = 100
+	}
+	if c.cfg.Name == "" {
+		c.cfg.Name = "errorz_errors_total"
+	}
+
+	return c
+}
+
+// Add counts an error.
+func (c *Collector) Add(err error) {
+	if err != nil {
+		c.AddSummary(&errorz.Summary{
+			ID:       errorz.GetID(err),
+			Status:   errorz.GetStatus(err),
+			Severity: errorz.GetSeverity(err),
+		})
+	}
+}
+
+// AddSummary counts a summarized error.
+func (c *Collector) AddSummary(summary *errorz.Summary) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.addSummary(summary)
+}
+
+// Send implements the errorz.Sink interface.
+func (c *Collector) Send(_ context.Context, summaries []*errorz.Summary) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for _, summary := range summaries {
+		c.addSummary(summary)
+	}
+
+	return nil
+}
+
+func (c *Collector) addSummary(summary *errorz.Summary) {
+	status := summary.Status
+	if status < 100 || status > 599 {
+		status = 0
+	}
+
+	severity := summary.Severity
+	if severity.String() == "" {
+		severity = 0
+	}
+
+	c.counts[sampleKey{id: c.getIDLabel(summary.ID), status: status, severity: severity}]++
+}
+
+func (c *Collector) getIDLabel(id errorz.ID) string {
+	if id == "" {
+		return NoneID
+	}
+
+	if c.cfg.IDs != nil {
+		label := ""
+		for _, tracked := range c.cfg.IDs {
+			if id.HasPrefix(string(tracked)) && len(tracked) > len(label) {
+				label = string(tracked)
+			}
+		}
+		if label == "" {
+			return OtherID
+		}
+		return label
+	}
+
+	if _, ok := c.ids[string(id)]; ok {
+		return string(id)
+	}
+	if len(c.ids) < c.cfg.MaxIDs {
+		c.ids[string(id)] = struct{}{}
+		return string(id)
+	}
+	return OtherID
+}
+
+// Samples returns the current counts, sorted by id, status and severity.
+func (c *Collector) Samples() []*Sample {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	samples := make([]*Sample, 0, len(c.counts))
+	for k, count := range c.counts {
+		samples = append(samples, &Sample{
+			ID:       k.id,
+			Status:   k.status,
+			Severity: k.severity,
+			Count:    count,
+		})
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].ID != samples[j].ID {
+			return samples[i].ID < samples[j].ID
+		}
+		if samples[i].Status != samples[j].Status {
+			return samples[i].Status < samples[j].Status
+		}
+		return samples[i].Severity < samples[j].Severity
+	})
+
+	return samples
+}
+
+// Reset resets all counts.
+func (c *Collector) Reset() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.ids = map[string]struct{}{}
+	c.counts = map[sampleKey]int64{}
+}
+
+// String implements the expvar.Var interface, returning the samples as a JSON array.
+func (c *Collector) String() string {
+	buf, err := json.Marshal(c.Samples())
+	errorz.MaybeMustWrap(err)
+	return string(buf)
+}
+
+// Handler returns an http.Handler serving the counts in the Prometheus text exposition format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		bw := bufio.NewWriter(w)
+
+		_, _ = bw.WriteString("# HELP " + c.cfg.Name + " Number of errors by id, status and severity.\n")
+		_, _ = bw.WriteString("# TYPE " + c.cfg.Name + " counter\n")
+
+		for _, s := range c.Samples() {
+			_, _ = bw.WriteString(c.cfg.Name +
+				`{id="` + escapeLabelValue(s.ID) +
+				`",severity="` + escapeLabelValue(getSeverityLabel(s.Severity)) +
+				`",status="` + strconv.Itoa(s.Status.Int()) +
+				`"} ` + strconv.FormatInt(s.Count, 10) + "\n")
+		}
+
+		_ = bw.Flush()
+	})
+}
+
+func getSeverityLabel(severity errorz.Severity) string {
+	if severity == 0 {
+		return NoneSeverity
+	}
+	return severity.String()
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
@@ -0,0 +1,130 @@
+package metricz_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/metricz"
+)
+
+func TestCollector(t *testing.T) {
+	c := metricz.NewCollector(nil)
+	require.Empty(t, c.Samples())
+
+	c.Add(errorz.Errorf("test error", errorz.ID("db.conn"), errorz.Status(http.StatusServiceUnavailable)))
+	c.Add(errorz.Errorf("test error", errorz.ID("db.conn"), errorz.Status(http.StatusServiceUnavailable)))
+	c.Add(errorz.Errorf("test error",
+		errorz.ID("db.conn"),
+		errorz.Status(http.StatusServiceUnavailable),
+		errorz.SeverityCritical))
+	c.Add(fmt.Errorf("test error"))
+	c.Add(nil)
+	c.AddSummary(&errorz.Summary{ID: "weird", Status: 1000, Severity: 42})
+
+	require.Equal(t, []*metricz.Sample{
+		{ID: "db.conn", Status: http.StatusServiceUnavailable, Severity: errorz.SeverityError, Count: 2},
+		{ID: "db.conn", Status: http.StatusServiceUnavailable, Severity: errorz.SeverityCritical, Count: 1},
+		{ID: metricz.NoneID, Status: 0, Severity: errorz.SeverityError, Count: 1},
+		{ID: "weird", Status: 0, Severity: 0, Count: 1},
+	}, c.Samples())
+
+	c.Reset()
+	require.Empty(t, c.Samples())
+}
+
+func TestCollectorMaxIDs(t *testing.T) {
+	c := metricz.NewCollector(&metricz.Config{MaxIDs: 2})
+
+	for i := 0; i < 4; i++ {
+		c.AddSummary(&errorz.Summary{ID: errorz.ID(fmt.Sprintf("id-%v", i)), Status: http.StatusBadRequest})
+		c.AddSummary(&errorz.Summary{ID: "id-0", Status: http.StatusBadRequest})
+	}
+
+	require.Equal(t, []*metricz.Sample{
+		{ID: "id-0", Status: http.StatusBadRequest, Count: 5},
+		{ID: "id-1", Status: http.StatusBadRequest, Count: 1},
+		{ID: metricz.OtherID, Status: http.StatusBadRequest, Count: 2},
+	}, c.Samples())
+}
+
+func TestCollectorIDs(t *testing.T) {
+	c := metricz.NewCollector(&metricz.Config{IDs: []errorz.ID{"db", "db.conn", "http.not-found"}})
+
+	ids := []errorz.ID{"db", "db.query", "db.conn", "db.conn.reset", "dbx", "http.not-found", "http.gone", ""}
+	for _, id := range ids {
+		c.AddSummary(&errorz.Summary{ID: id})
+	}
+
+	for i := 0; i < 200; i++ {
+		c.AddSummary(&errorz.Summary{ID: errorz.ID(fmt.Sprintf("db.query.%v", i))})
+	}
+
+	require.Equal(t, []*metricz.Sample{
+		{ID: "db", Count: 202},
+		{ID: "db.conn", Count: 2},
+		{ID: "http.not-found", Count: 1},
+		{ID: metricz.NoneID, Count: 1},
+		{ID: metricz.OtherID, Count: 2},
+	}, c.Samples())
+}
+
+func TestCollectorSink(t *testing.T) {
+	c := metricz.NewCollector(nil)
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{c}})
+
+	for i := 0; i < 3; i++ {
+		r.Report(context.Background(), errorz.Errorf("test error", errorz.ID("id"), errorz.Status(http.StatusNotFound)))
+	}
+
+	require.NoError(t, r.Close(context.Background()))
+	require.Equal(t, []*metricz.Sample{
+		{ID: "id", Status: http.StatusNotFound, Severity: errorz.SeverityWarning, Count: 3},
+	}, c.Samples())
+}
+
+func TestCollectorExpvar(t *testing.T) {
+	c := metricz.NewCollector(nil)
+	expvar.Publish("metricz-test", c)
+	require.Equal(t, "[]", expvar.Get("metricz-test").String())
+
+	c.AddSummary(&errorz.Summary{ID: "id", Status: http.StatusNotFound, Severity: errorz.SeverityWarning})
+
+	var samples []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("metricz-test").String()), &samples))
+	require.Equal(t, []map[string]interface{}{
+		{"id": "id", "status": float64(404), "severity": "warning", "count": float64(1)},
+	}, samples)
+}
+
+func TestCollectorHandler(t *testing.T) {
+	c := metricz.NewCollector(&metricz.Config{Name: "app_errors_total"})
+	c.AddSummary(&errorz.Summary{ID: "db.conn", Status: http.StatusServiceUnavailable, Severity: errorz.SeverityError})
+	c.AddSummary(&errorz.Summary{ID: "db.conn", Status: http.StatusServiceUnavailable, Severity: errorz.SeverityError})
+	c.AddSummary(&errorz.Summary{ID: "quote\"back\\slash\nnewline"})
+
+	srv := httptest.NewServer(c.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer errorz.IgnoreClose(resp.Body)
+
+	buf, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, metricz.ContentType, resp.Header.Get("Content-Type"))
+	require.Equal(t, ""+
+		"# HELP app_errors_total Number of errors by id, status and severity.\n"+
+		"# TYPE app_errors_total counter\n"+
+		`app_errors_total{id="db.conn",severity="error",status="503"} 2`+"\n"+
+		`app_errors_total{id="quote\"back\\slash\nnewline",severity="none",status="0"} 1`+"\n",
+		string(buf))
+}
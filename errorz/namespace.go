@@ -0,0 +1,54 @@
+package errorz
+
+import (
+	"sync"
+)
+
+var (
+	namespacesM = &sync.RWMutex{}
+	namespaces  = map[ID]NamespaceDefaults{}
+)
+
+// NamespaceDefaults describes defaults applied to errors whose id falls within a namespace.
+// Zero-valued fields are ignored, i.e. they are inherited from parent namespaces.
+type NamespaceDefaults struct {
+	Status   Status
+	Severity Severity
+}
+
+// SetNamespaceDefaults registers defaults for errors whose id falls within the given namespace.
+// Defaults are used only when the corresponding value has not been set explicitly on the error.
+func SetNamespaceDefaults(namespace ID, defaults NamespaceDefaults) {
+	namespacesM.Lock()
+	defer namespacesM.Unlock()
+	namespaces[namespace] = defaults
+}
+
+// ClearNamespaceDefaults removes the defaults registered for the given namespace.
+func ClearNamespaceDefaults(namespace ID) {
+	namespacesM.Lock()
+	defer namespacesM.Unlock()
+	delete(namespaces, namespace)
+}
+
+// GetNamespaceDefaults resolves the defaults for the given id, merging the registered defaults of the id itself and
+// of all its parent namespaces, with the most specific namespace taking precedence.
+func GetNamespaceDefaults(id ID) NamespaceDefaults {
+	namespacesM.RLock()
+	defer namespacesM.RUnlock()
+
+	merged := NamespaceDefaults{}
+
+	for ; id != ""; id = id.Parent() {
+		if defaults, ok := namespaces[id]; ok {
+			if merged.Status == 0 {
+				merged.Status = defaults.Status
+			}
+			if merged.Severity == 0 {
+				merged.Severity = defaults.Severity
+			}
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,43 @@
+package errorz
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	normalizeLocationRegexp = regexp.MustCompile(` \((?:.*[/\\])?([^/\\]+):\d+\)$`)
+	normalizePCRegexp       = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+)
+
+// NormalizeStackFrame replaces the non-deterministic parts of a stack frame formatted by FormatStackTrace with
+// placeholders: directories with "<path>", line numbers with "<line>", and PCs with "<pc>". Function and file names
+// are kept, e.g. "pkg.Func (/abs/dir/file.go:12)" becomes "pkg.Func (<path>/file.go:<line>)".
+func NormalizeStackFrame(frame string) string {
+	frame = normalizeLocationRegexp.ReplaceAllString(frame, " (<path>/$1:<line>)")
+	return normalizePCRegexp.ReplaceAllString(frame, "<pc>")
+}
+
+// Normalize returns a copy of the Summary with a normalized stack trace (see NormalizeStackFrame), suitable for
+// snapshot testing. Frames from the runtime package are removed, as they depend on the platform. The fingerprint, if
+// any, is replaced with "<fingerprint>", as the frames it hashes depend on the module path and on inlining decisions
+// made by the compiler. The time, if any, is removed.
+func (s *Summary) Normalize() *Summary {
+	normalized := *s
+	normalized.Time = nil
+
+	if s.Fingerprint != "" {
+		normalized.Fingerprint = "<fingerprint>"
+	}
+
+	if s.StackTrace != nil {
+		normalized.StackTrace = make([]string, 0, len(s.StackTrace))
+		for _, frame := range s.StackTrace {
+			if !strings.HasPrefix(frame, "runtime.") {
+				normalized.StackTrace = append(normalized.StackTrace, NormalizeStackFrame(frame))
+			}
+		}
+	}
+
+	return &normalized
+}
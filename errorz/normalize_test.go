@@ -0,0 +1,40 @@
+package errorz_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestNormalizeStackFrame(t *testing.T) {
+	require.Equal(t, "pkg.Func (<path>/file.go:<line>)", errorz.NormalizeStackFrame("pkg.Func (/abs/dir/file.go:12)"))
+	require.Equal(t, "pkg.Func (<path>/file.go:<line>)", errorz.NormalizeStackFrame(`pkg.Func (C:\dir\file.go:12)`))
+	require.Equal(t, "pkg.Func (<path>/file.go:<line>)", errorz.NormalizeStackFrame("pkg.Func (file.go:12)"))
+	require.Equal(t, "pkg.(*T).Func (<path>/file.go:<line>)", errorz.NormalizeStackFrame("pkg.(*T).Func (/a/b/file.go:1)"))
+	require.Equal(t, "pkg.Func at <pc>", errorz.NormalizeStackFrame("pkg.Func at 0x1f2E"))
+	require.Equal(t, "pkg.Func", errorz.NormalizeStackFrame("pkg.Func"))
+}
+
+func TestSummaryNormalize(t *testing.T) {
+	s := errorz.ToSummary(errorz.Errorf("test error", errorz.Status(http.StatusNotFound)))
+	n := s.Normalize()
+	require.NotSame(t, s, n)
+	require.Equal(t, s.Message, n.Message)
+	require.Equal(t, s.Status, n.Status)
+	require.Equal(t, "<fingerprint>", n.Fingerprint)
+	require.Equal(t, "errorz_test.TestSummaryNormalize (<path>/normalize_test.go:<line>)", n.StackTrace[0])
+	require.Equal(t, "testing.tRunner (<path>/testing.go:<line>)", n.StackTrace[len(n.StackTrace)-1])
+	require.NotEqual(t, s.StackTrace[0], n.StackTrace[0])
+
+	require.Nil(t, (&errorz.Summary{}).Normalize().StackTrace)
+	require.Empty(t, (&errorz.Summary{}).Normalize().Fingerprint)
+
+	now := time.Now()
+	s.Time = &now
+	require.Nil(t, s.Normalize().Time)
+	require.Same(t, &now, s.Time)
+}
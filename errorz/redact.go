@@ -0,0 +1,45 @@
+package errorz
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultRedactedQueryParams lists the query parameters redacted by default by RedactURL.
+var DefaultRedactedQueryParams = []string{
+	"access_token", "api_key", "apikey", "auth", "code", "key", "password", "secret", "signature", "sig", "token",
+}
+
+// RedactURL formats the URL replacing secrets with "xxxxx": the userinfo password and the values of the given query
+// parameters (matched case-insensitively). If params is nil, DefaultRedactedQueryParams is used.
+func RedactURL(u *url.URL, params []string) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+
+	if redacted.User != nil {
+		if _, ok := redacted.User.Password(); ok {
+			redacted.User = url.UserPassword(redacted.User.Username(), "xxxxx")
+		}
+	}
+
+	if redacted.RawQuery != "" {
+		if params == nil {
+			params = DefaultRedactedQueryParams
+		}
+
+		query := redacted.Query()
+		for k := range query {
+			for _, param := range params {
+				if strings.EqualFold(k, param) {
+					query[k] = []string{"xxxxx"}
+				}
+			}
+		}
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
+}
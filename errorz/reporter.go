@@ -0,0 +1,317 @@
+package errorz
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	defaultReporter atomic.Pointer[Reporter]
+)
+
+// ReporterConfig describes the configuration of a Reporter.
+type ReporterConfig struct {
+	// Sinks receive the batches of reported errors, in order.
+	Sinks []Sink
+	// QueueSize is the maximum number of errors waiting to be processed, further errors are dropped (default 1024).
+	QueueSize int
+	// BatchSize is the maximum number of errors sent to the sinks at once (default 100).
+	BatchSize int
+	// FlushInterval is the maximum time errors wait in a partial batch (default 1s).
+	FlushInterval time.Duration
+	// SampleRate is the fraction of errors which are reported, between 0 and 1 (0 means all errors are reported).
+	SampleRate float64
+	// RateLimit is the maximum number of errors with the same key reported in each RateLimitInterval (0 for unlimited).
+	RateLimit int
+	// RateLimitInterval is the duration of the rate limiting window (default 1m).
+	RateLimitInterval time.Duration
+	// Key returns the key used for rate limiting (default: the fingerprint, see Fingerprint).
+	Key func(s *Summary) string
+	// SinkTimeout bounds the time spent sending each batch to each sink (default 10s).
+	SinkTimeout time.Duration
+	// OnError is called with errors returned by the sinks (by default they are ignored).
+	OnError func(err error)
+}
+
+// ReporterStats describes the counters of a Reporter.
+type ReporterStats struct {
+	Reported    int64 `json:"reported"`
+	Sampled     int64 `json:"sampled"`
+	Dropped     int64 `json:"dropped"`
+	RateLimited int64 `json:"rateLimited"`
+	Sent        int64 `json:"sent"`
+	Failed      int64 `json:"failed"`
+}
+
+// Reporter asynchronously reports errors to a set of sinks. It is safe for concurrent use.
+type Reporter struct {
+	cfg     ReporterConfig
+	queue   chan *Summary
+	flushC  chan chan struct{}
+	closeC  chan struct{}
+	doneC   chan struct{}
+	closeM  sync.RWMutex
+	closed  bool
+	limits  map[string]int
+	resetAt time.Time
+	stats   ReporterStats
+}
+
+// NewReporter initializes a new Reporter and starts its background goroutine, which runs until Close is called.
+func NewReporter(cfg *ReporterConfig) *Reporter {
+	r := &Reporter{
+		limits: map[string]int{},
+	}
+
+	if cfg != nil {
+		r.cfg = *cfg
+	}
+	if r.cfg.QueueSize <= 0 {
+		r.cfg.QueueSize = 1024
+	}
+	if r.cfg.BatchSize <= 0 {
+		r.cfg.BatchSize = 100
+	}
+	if r.cfg.FlushInterval <= 0 {
+		r.cfg.FlushInterval = time.Second
+	}
+	if r.cfg.RateLimitInterval <= 0 {
+		r.cfg.RateLimitInterval = time.Minute
+	}
+	if r.cfg.Key == nil {
+		r.cfg.Key = defaultReporterKey
+	}
+	if r.cfg.SinkTimeout <= 0 {
+		r.cfg.SinkTimeout = 10 * time.Second
+	}
+
+	r.queue = make(chan *Summary, r.cfg.QueueSize)
+	r.flushC = make(chan chan struct{})
+	r.closeC = make(chan struct{})
+	r.doneC = make(chan struct{})
+
+	go r.run()
+	return r
+}
+
+// SetReporter sets the Reporter used by Report, nil to disable reporting.
+func SetReporter(r *Reporter) {
+	defaultReporter.Store(r)
+}
+
+// GetReporter returns the Reporter used by Report, nil if not set.
+func GetReporter() *Reporter {
+	return defaultReporter.Load()
+}
+
+// Report reports the error using the Reporter set by SetReporter. It does nothing if the error is nil or no Reporter
+// is set.
+func Report(ctx context.Context, err error) {
+	if r := defaultReporter.Load(); r != nil {
+		r.report(ctx, err, Skip())
+	}
+}
+
+// Report enqueues the error for reporting, it never blocks. The error is first wrapped using WrapCtx, so that the
+// default options carried by the context fill in what is not set on it (without modifying it), and summarized (see
+// ToSummary) with the current time, so that sinks see when it was reported rather than when they received it. It
+// does nothing if the error is nil, and drops it if sampled out, if the queue is full, or if the
+// Reporter is closed.
+func (r *Reporter) Report(ctx context.Context, err error) {
+	r.report(ctx, err, Skip())
+}
+
+func (r *Reporter) report(ctx context.Context, err error, options ...Option) {
+	if err == nil {
+		return
+	}
+
+	if r.cfg.SampleRate > 0 && r.cfg.SampleRate < 1 && rand.Float64() >= r.cfg.SampleRate {
+		atomic.AddInt64(&r.stats.Sampled, 1)
+		return
+	}
+
+	s := ToSummary(WrapCtx(ctx, err, append(options, Skip())...))
+	now := time.Now()
+	s.Time = &now
+
+	r.closeM.RLock()
+	defer r.closeM.RUnlock()
+
+	if r.closed {
+		atomic.AddInt64(&r.stats.Dropped, 1)
+		return
+	}
+
+	select {
+	case r.queue <- s:
+		atomic.AddInt64(&r.stats.Reported, 1)
+	default:
+		atomic.AddInt64(&r.stats.Dropped, 1)
+	}
+}
+
+// Flush sends all the enqueued errors to the sinks, waiting until done or the context is done.
+func (r *Reporter) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+
+	select {
+	case r.flushC <- done:
+	case <-r.doneC:
+		return nil
+	case <-ctx.Done():
+		return WrapCtx(ctx, ctx.Err())
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return WrapCtx(ctx, ctx.Err())
+	}
+}
+
+// Close stops accepting errors, sends all the enqueued ones to the sinks and stops the background goroutine, waiting
+// until done or the context is done. Further calls do nothing.
+func (r *Reporter) Close(ctx context.Context) error {
+	r.closeM.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.closeC)
+	}
+	r.closeM.Unlock()
+
+	select {
+	case <-r.doneC:
+		return nil
+	case <-ctx.Done():
+		return WrapCtx(ctx, ctx.Err())
+	}
+}
+
+// Stats returns a snapshot of the counters of the Reporter.
+func (r *Reporter) Stats() ReporterStats {
+	return ReporterStats{
+		Reported:    atomic.LoadInt64(&r.stats.Reported),
+		Sampled:     atomic.LoadInt64(&r.stats.Sampled),
+		Dropped:     atomic.LoadInt64(&r.stats.Dropped),
+		RateLimited: atomic.LoadInt64(&r.stats.RateLimited),
+		Sent:        atomic.LoadInt64(&r.stats.Sent),
+		Failed:      atomic.LoadInt64(&r.stats.Failed),
+	}
+}
+
+func (r *Reporter) run() {
+	defer close(r.doneC)
+
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Summary, 0, r.cfg.BatchSize)
+
+	for {
+		select {
+		case s := <-r.queue:
+			batch = r.add(batch, s)
+		case <-ticker.C:
+			batch = r.send(batch)
+		case done := <-r.flushC:
+			batch = r.send(r.drain(batch))
+			close(done)
+		case <-r.closeC:
+			r.send(r.drain(batch))
+			return
+		}
+	}
+}
+
+func (r *Reporter) drain(batch []*Summary) []*Summary {
+	for {
+		select {
+		case s := <-r.queue:
+			batch = r.add(batch, s)
+		default:
+			return batch
+		}
+	}
+}
+
+func (r *Reporter) add(batch []*Summary, s *Summary) []*Summary {
+	if !r.allow(s) {
+		atomic.AddInt64(&r.stats.RateLimited, 1)
+		return batch
+	}
+
+	if batch = append(batch, s); len(batch) >= r.cfg.BatchSize {
+		return r.send(batch)
+	}
+	return batch
+}
+
+// allow implements fixed-window rate limiting by key. Only called from the background goroutine.
+func (r *Reporter) allow(s *Summary) bool {
+	if r.cfg.RateLimit <= 0 {
+		return true
+	}
+
+	if now := time.Now(); now.After(r.resetAt) {
+		r.limits = map[string]int{}
+		r.resetAt = now.Add(r.cfg.RateLimitInterval)
+	}
+
+	key := r.cfg.Key(s)
+	if r.limits[key] >= r.cfg.RateLimit {
+		return false
+	}
+
+	r.limits[key]++
+	return true
+}
+
+func (r *Reporter) send(batch []*Summary) []*Summary {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	failed := false
+	for _, sink := range r.cfg.Sinks {
+		if err := r.sendToSink(sink, batch); err != nil {
+			failed = true
+			if r.cfg.OnError != nil {
+				r.cfg.OnError(err)
+			}
+		}
+	}
+
+	if failed {
+		atomic.AddInt64(&r.stats.Failed, int64(len(batch)))
+	} else {
+		atomic.AddInt64(&r.stats.Sent, int64(len(batch)))
+	}
+
+	return make([]*Summary, 0, r.cfg.BatchSize)
+}
+
+func (r *Reporter) sendToSink(sink Sink, batch []*Summary) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.SinkTimeout)
+	defer cancel()
+
+	defer func() {
+		if rErr := MaybeWrapRecover(recover()); rErr != nil {
+			err = rErr
+		}
+	}()
+
+	return MaybeWrap(sink.Send(ctx, batch))
+}
+
+func defaultReporterKey(s *Summary) string {
+	if s.Fingerprint != "" {
+		return s.Fingerprint
+	}
+	return fmt.Sprintf("%v\n%v", s.ID, s.Message)
+}
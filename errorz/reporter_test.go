@@ -0,0 +1,230 @@
+package errorz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type testSink struct {
+	m       sync.Mutex
+	batches [][]*errorz.Summary
+}
+
+// Send implements the errorz.Sink interface.
+func (s *testSink) Send(_ context.Context, summaries []*errorz.Summary) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.batches = append(s.batches, summaries)
+	return nil
+}
+
+func (s *testSink) getBatches() [][]*errorz.Summary {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.batches
+}
+
+func TestReporter(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, FlushInterval: time.Hour})
+	defer func() { require.NoError(t, r.Close(context.Background())) }()
+
+	ctx := errorz.WithOptions(context.Background(), errorz.M("k", "v"))
+	start := time.Now()
+	r.Report(ctx, fmt.Errorf("test error"))
+	r.Report(ctx, nil)
+	require.NoError(t, r.Flush(context.Background()))
+
+	batches := sink.getBatches()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 1)
+	require.Equal(t, "test error", batches[0][0].Message)
+	require.Equal(t, "v", batches[0][0].Metadata["k"])
+	require.Contains(t, batches[0][0].StackTrace[0], "TestReporter")
+	require.NotNil(t, batches[0][0].Time)
+	require.False(t, batches[0][0].Time.Before(start))
+	require.Equal(t, errorz.ReporterStats{Reported: 1, Sent: 1}, r.Stats())
+}
+
+func TestReporterKeepsError(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, FlushInterval: time.Hour})
+	defer func() { require.NoError(t, r.Close(context.Background())) }()
+
+	err := errorz.Errorf("test error", errorz.M("k1", "v1"))
+	ctx := errorz.WithOptions(context.Background(), errorz.ID("id"), errorz.M("k2", "v2"))
+	r.Report(ctx, err)
+	require.NoError(t, r.Flush(context.Background()))
+
+	batches := sink.getBatches()
+	require.Len(t, batches, 1)
+	require.Equal(t, errorz.ID("id"), batches[0][0].ID)
+	require.Equal(t, map[string]interface{}{"k1": "v1", "k2": "v2"}, batches[0][0].Metadata)
+	require.Equal(t, errorz.ID(""), errorz.GetID(err))
+	require.Equal(t, errorz.Metadata{"k1": "v1"}, errorz.GetMetadata(err))
+}
+
+func TestReporterContextDefaults(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, FlushInterval: time.Hour})
+	defer func() { require.NoError(t, r.Close(context.Background())) }()
+
+	ctx := errorz.WithOptions(context.Background(),
+		errorz.ID("default"),
+		errorz.Status(http.StatusInternalServerError),
+		errorz.M("k", "default"))
+
+	err := errorz.Errorf("test error", errorz.ID("user-not-found"), errorz.Status(http.StatusNotFound), errorz.M("k", "inner"))
+	r.Report(ctx, err)
+	r.Report(ctx, fmt.Errorf("test error"))
+	require.NoError(t, r.Flush(context.Background()))
+
+	batches := sink.getBatches()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 2)
+	require.Equal(t, errorz.ID("user-not-found"), batches[0][0].ID)
+	require.Equal(t, errorz.Status(http.StatusNotFound), batches[0][0].Status)
+	require.Equal(t, "inner", batches[0][0].Metadata["k"])
+	require.Equal(t, errorz.ID("default"), batches[0][1].ID)
+	require.Equal(t, errorz.Status(http.StatusInternalServerError), batches[0][1].Status)
+	require.Equal(t, "default", batches[0][1].Metadata["k"])
+	require.Equal(t, errorz.ID("user-not-found"), errorz.GetID(err))
+}
+
+func TestReporterBatching(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, BatchSize: 2, FlushInterval: time.Hour})
+
+	for i := 0; i < 5; i++ {
+		r.Report(context.Background(), fmt.Errorf("test error %v", i))
+	}
+
+	require.NoError(t, r.Close(context.Background()))
+	require.NoError(t, r.Close(context.Background()))
+	require.NoError(t, r.Flush(context.Background()))
+
+	batches := sink.getBatches()
+	require.Len(t, batches, 3)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[1], 2)
+	require.Len(t, batches[2], 1)
+	require.Equal(t, "test error 4", batches[2][0].Message)
+
+	r.Report(context.Background(), fmt.Errorf("test error"))
+	require.Equal(t, errorz.ReporterStats{Reported: 5, Dropped: 1, Sent: 5}, r.Stats())
+}
+
+func TestReporterFlushInterval(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, FlushInterval: time.Millisecond})
+	defer func() { require.NoError(t, r.Close(context.Background())) }()
+
+	r.Report(context.Background(), fmt.Errorf("test error"))
+	require.Eventually(t, func() bool { return len(sink.getBatches()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestReporterSampling(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, SampleRate: 1e-12})
+
+	for i := 0; i < 10; i++ {
+		r.Report(context.Background(), fmt.Errorf("test error"))
+	}
+
+	require.NoError(t, r.Close(context.Background()))
+	require.Empty(t, sink.getBatches())
+	require.Equal(t, errorz.ReporterStats{Sampled: 10}, r.Stats())
+}
+
+func TestReporterRateLimit(t *testing.T) {
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}, RateLimit: 2})
+
+	for i := 0; i < 5; i++ {
+		r.Report(context.Background(), fmt.Errorf("test error"))
+		r.Report(context.Background(), errorz.Errorf("other error", errorz.ID("other")))
+	}
+
+	require.NoError(t, r.Close(context.Background()))
+	require.Len(t, sink.getBatches(), 1)
+	require.Len(t, sink.getBatches()[0], 4)
+	require.Equal(t, errorz.ReporterStats{Reported: 10, RateLimited: 6, Sent: 4}, r.Stats())
+}
+
+func TestReporterQueueFull(t *testing.T) {
+	block := make(chan struct{})
+	r := errorz.NewReporter(&errorz.ReporterConfig{
+		Sinks: []errorz.Sink{
+			errorz.SinkFunc(func(context.Context, []*errorz.Summary) error {
+				<-block
+				return nil
+			}),
+		},
+		QueueSize: 1,
+		BatchSize: 1,
+	})
+
+	for i := 0; i < 10; i++ {
+		r.Report(context.Background(), fmt.Errorf("test error"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, r.Flush(ctx), context.DeadlineExceeded)
+	require.ErrorIs(t, r.Close(ctx), context.DeadlineExceeded)
+
+	close(block)
+	require.NoError(t, r.Close(context.Background()))
+
+	stats := r.Stats()
+	require.Greater(t, stats.Dropped, int64(0))
+	require.Equal(t, int64(10), stats.Reported+stats.Dropped)
+	require.Equal(t, stats.Reported, stats.Sent)
+}
+
+func TestReporterSinkErrors(t *testing.T) {
+	m := &sync.Mutex{}
+	var errs []string
+
+	r := errorz.NewReporter(&errorz.ReporterConfig{
+		Sinks: []errorz.Sink{
+			errorz.SinkFunc(func(context.Context, []*errorz.Summary) error { return fmt.Errorf("sink error") }),
+			errorz.SinkFunc(func(context.Context, []*errorz.Summary) error { panic("sink panic") }),
+		},
+		OnError: func(err error) {
+			m.Lock()
+			defer m.Unlock()
+			errs = append(errs, err.Error())
+		},
+	})
+
+	r.Report(context.Background(), fmt.Errorf("test error"))
+	require.NoError(t, r.Close(context.Background()))
+	require.Equal(t, []string{"sink error", "sink panic"}, errs)
+	require.Equal(t, errorz.ReporterStats{Reported: 1, Failed: 1}, r.Stats())
+}
+
+func TestReport(t *testing.T) {
+	require.Nil(t, errorz.GetReporter())
+	errorz.Report(context.Background(), fmt.Errorf("test error"))
+
+	sink := &testSink{}
+	r := errorz.NewReporter(&errorz.ReporterConfig{Sinks: []errorz.Sink{sink}})
+	errorz.SetReporter(r)
+	defer errorz.SetReporter(nil)
+	require.Equal(t, r, errorz.GetReporter())
+
+	errorz.Report(context.Background(), fmt.Errorf("test error"))
+	require.NoError(t, r.Close(context.Background()))
+	require.Len(t, sink.getBatches(), 1)
+	require.True(t, strings.Contains(sink.getBatches()[0][0].StackTrace[0], "TestReport"))
+}
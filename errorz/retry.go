@@ -0,0 +1,175 @@
+package errorz
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// Retryable marks the error as retryable (or explicitly non-retryable).
+func Retryable(retryable bool) OptionFunc {
+	return func(err error) {
+		if e, ok := err.(*wrappedError); ok {
+			e.retryable = &retryable
+		}
+	}
+}
+
+// IsRetryable returns true if the error can be retried. The error chain is inspected from the outermost error, and
+// the first of the following rules that applies determines the result:
+//   - an explicit Retryable option;
+//   - context.Canceled (non-retryable) and context.DeadlineExceeded (retryable);
+//   - a "Temporary() bool" or "Timeout() bool" method returning true (retryable);
+//   - the classification of the error (see GetClassification).
+//
+// Errors matching none of the rules are considered non-retryable.
+func IsRetryable(err error) bool {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if e, ok := cur.(*wrappedError); ok && e.retryable != nil {
+			return *e.retryable
+		}
+
+		switch cur {
+		case context.Canceled:
+			return false
+		case context.DeadlineExceeded:
+			return true
+		}
+
+		if t, ok := cur.(interface{ Temporary() bool }); ok && t.Temporary() {
+			return true
+		}
+
+		if t, ok := cur.(interface{ Timeout() bool }); ok && t.Timeout() {
+			return true
+		}
+	}
+
+	if c, ok := GetClassification(err); ok && c.Retryable != nil {
+		return *c.Retryable
+	}
+
+	return false
+}
+
+// MinRetryDelay is the minimum delay between attempts made by Retry, applied to the jittered delay.
+const MinRetryDelay = time.Millisecond
+
+// RetryPolicy describes how Retry retries a failing function.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one (0 for unlimited).
+	MaxAttempts int
+	// InitialDelay is the delay after the first failed attempt (values below MinRetryDelay are raised to it).
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between attempts (0 for uncapped).
+	MaxDelay time.Duration
+	// Multiplier is applied to the delay after each failed attempt (values below 1 are treated as 1).
+	Multiplier float64
+	// Jitter randomizes each delay by up to the given fraction of it, in both directions (e.g. 0.2 for ±20%).
+	Jitter float64
+	// MaxElapsedTime stops retrying once the next attempt, after the jittered delay, would start after the given time
+	// (0 for unlimited).
+	MaxElapsedTime time.Duration
+	// IsRetryable determines whether an error can be retried, defaults to IsRetryable.
+	IsRetryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns a reasonable default retry policy.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// RetryAttempt describes a failed attempt made by Retry.
+type RetryAttempt struct {
+	Error string        `json:"error"`
+	Delay time.Duration `json:"delay"`
+}
+
+// Retry calls f until it succeeds, it returns a non-retryable error, or the policy (or context) does not allow
+// further attempts. The error from the last attempt is returned, with the list of all attempts (as []RetryAttempt)
+// attached as metadata under the "retry-attempts" key.
+func Retry(ctx context.Context, policy *RetryPolicy, f func(ctx context.Context) error) error {
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	isRetryable := policy.IsRetryable
+	if isRetryable == nil {
+		isRetryable = IsRetryable
+	}
+
+	start := time.Now()
+	delay := policy.InitialDelay
+	attempts := make([]RetryAttempt, 0)
+
+	for {
+		err := f(ctx)
+		if err == nil {
+			return nil
+		}
+
+		attempts = append(attempts, RetryAttempt{Error: err.Error()})
+
+		if !isRetryable(err) || (policy.MaxAttempts > 0 && len(attempts) >= policy.MaxAttempts) {
+			return Wrap(err, M("retry-attempts", attempts), Skip())
+		}
+
+		jittered := jitter(delay, policy.Jitter)
+		if jittered < MinRetryDelay {
+			jittered = MinRetryDelay
+		}
+
+		if policy.MaxElapsedTime > 0 && time.Since(start)+jittered > policy.MaxElapsedTime {
+			return Wrap(err, M("retry-attempts", attempts), Skip())
+		}
+
+		attempts[len(attempts)-1].Delay = jittered
+
+		if ctxErr := sleep(ctx, jittered); ctxErr != nil {
+			return Wrap(err, M("retry-attempts", attempts), M("retry-context-error", ctxErr.Error()), Skip())
+		}
+
+		delay = nextDelay(delay, policy)
+	}
+}
+
+func nextDelay(delay time.Duration, policy *RetryPolicy) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay = time.Duration(float64(delay) * multiplier)
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	return delay
+}
+
+func jitter(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	return time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
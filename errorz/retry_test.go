@@ -0,0 +1,137 @@
+package errorz_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type testTemporaryError struct {
+	temporary bool
+}
+
+// Error implements the error interface.
+func (e *testTemporaryError) Error() string {
+	return "temporary error"
+}
+
+// Temporary returns true if the error is temporary.
+func (e *testTemporaryError) Temporary() bool {
+	return e.temporary
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.False(t, errorz.IsRetryable(nil))
+	require.False(t, errorz.IsRetryable(fmt.Errorf("test error")))
+	require.False(t, errorz.IsRetryable(errorz.Errorf("test error")))
+	require.True(t, errorz.IsRetryable(errorz.Errorf("test error", errorz.Retryable(true))))
+	require.False(t, errorz.IsRetryable(errorz.Errorf("test error", errorz.Retryable(false))))
+	require.True(t, errorz.IsRetryable(&testTemporaryError{temporary: true}))
+	require.False(t, errorz.IsRetryable(&testTemporaryError{temporary: false}))
+	require.True(t, errorz.IsRetryable(errorz.Wrap(fmt.Errorf("wrapped: %w", &testTemporaryError{temporary: true}))))
+	require.False(t, errorz.IsRetryable(errorz.Wrap(&testTemporaryError{temporary: true}, errorz.Retryable(false))))
+	require.True(t, errorz.IsRetryable(errorz.Wrap(context.DeadlineExceeded)))
+	require.False(t, errorz.IsRetryable(errorz.Wrap(fmt.Errorf("wrapped: %w", context.Canceled))))
+	require.True(t, errorz.IsRetryable(&net.DNSError{IsTimeout: true}))
+	require.True(t, errorz.IsRetryable(fmt.Errorf("wrapped: %w", errorz.Errorf("test error", errorz.Retryable(true)))))
+}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	require.NoError(t, errorz.Retry(context.Background(), nil, func(_ context.Context) error {
+		calls++
+		return nil
+	}))
+	require.Equal(t, 1, calls)
+
+	calls = 0
+	require.NoError(t, errorz.Retry(context.Background(), &errorz.RetryPolicy{MaxAttempts: 5, InitialDelay: time.Millisecond, Jitter: 0.5}, func(_ context.Context) error {
+		if calls++; calls < 3 {
+			return errorz.Errorf("test error", errorz.Retryable(true))
+		}
+		return nil
+	}))
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	err := errorz.Retry(context.Background(), &errorz.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}, func(_ context.Context) error {
+		calls++
+		return errorz.Errorf("test error %v", errorz.A(calls), errorz.Retryable(true))
+	})
+	require.EqualError(t, err, "test error 3")
+	require.Equal(t, 3, calls)
+	require.Equal(t, []errorz.RetryAttempt{
+		{Error: "test error 1", Delay: time.Millisecond},
+		{Error: "test error 2", Delay: 2 * time.Millisecond},
+		{Error: "test error 3"},
+	}, errorz.GetMetadata(err).Get("retry-attempts"))
+
+	calls = 0
+	err = errorz.Retry(context.Background(), &errorz.RetryPolicy{MaxAttempts: 3}, func(_ context.Context) error {
+		calls++
+		return fmt.Errorf("test error")
+	})
+	require.EqualError(t, err, "test error")
+	require.Equal(t, 1, calls)
+	require.Equal(t, []errorz.RetryAttempt{{Error: "test error"}}, errorz.GetMetadata(err).Get("retry-attempts"))
+
+	calls = 0
+	err = errorz.Retry(context.Background(), &errorz.RetryPolicy{
+		InitialDelay:   time.Millisecond,
+		MaxDelay:       2 * time.Millisecond,
+		Multiplier:     10,
+		MaxElapsedTime: 20 * time.Millisecond,
+		IsRetryable:    func(error) bool { return true },
+	}, func(_ context.Context) error {
+		calls++
+		return fmt.Errorf("test error")
+	})
+	require.EqualError(t, err, "test error")
+	require.Greater(t, calls, 2)
+	for _, attempt := range errorz.GetMetadata(err).Get("retry-attempts").([]errorz.RetryAttempt) {
+		require.LessOrEqual(t, attempt.Delay, 2*time.Millisecond)
+	}
+
+	err = errorz.Retry(context.Background(), &errorz.RetryPolicy{
+		MaxElapsedTime: 10 * time.Millisecond,
+		IsRetryable:    func(error) bool { return true },
+	}, func(_ context.Context) error {
+		return fmt.Errorf("test error")
+	})
+	require.EqualError(t, err, "test error")
+	attempts := errorz.GetMetadata(err).Get("retry-attempts").([]errorz.RetryAttempt)
+	require.Less(t, len(attempts), 20)
+	for _, attempt := range attempts[:len(attempts)-1] {
+		require.Equal(t, errorz.MinRetryDelay, attempt.Delay)
+	}
+
+	for i := 0; i < 10; i++ {
+		err = errorz.Retry(context.Background(), &errorz.RetryPolicy{
+			InitialDelay:   2 * time.Millisecond,
+			Jitter:         1,
+			MaxElapsedTime: 6 * time.Millisecond,
+			IsRetryable:    func(error) bool { return true },
+		}, func(_ context.Context) error {
+			return fmt.Errorf("test error")
+		})
+		total := time.Duration(0)
+		for _, attempt := range errorz.GetMetadata(err).Get("retry-attempts").([]errorz.RetryAttempt) {
+			total += attempt.Delay
+		}
+		require.LessOrEqual(t, total, 6*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = errorz.Retry(ctx, &errorz.RetryPolicy{InitialDelay: time.Hour}, func(_ context.Context) error {
+		cancel()
+		return errorz.Errorf("test error", errorz.Retryable(true))
+	})
+	require.EqualError(t, err, "test error")
+	require.Equal(t, "context canceled", errorz.GetMetadata(err).Get("retry-context-error"))
+}
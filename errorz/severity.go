@@ -0,0 +1,135 @@
+package errorz
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ Option = Severity(0)
+)
+
+// Severity describes how severe an error is.
+type Severity int
+
+// Known severities, in ascending order. The zero value means that severity is not set.
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var (
+	severityNames = map[Severity]string{
+		SeverityDebug:    "debug",
+		SeverityInfo:     "info",
+		SeverityWarning:  "warning",
+		SeverityError:    "error",
+		SeverityCritical: "critical",
+	}
+
+	severityLogLevels = map[Severity]int{
+		SeverityDebug:    -4,
+		SeverityInfo:     0,
+		SeverityWarning:  4,
+		SeverityError:    8,
+		SeverityCritical: 12,
+	}
+)
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return ""
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// Unknown severities are marshaled as their numeric value, which UnmarshalText accepts.
+func (s Severity) MarshalText() ([]byte, error) {
+	if name := s.String(); name != "" {
+		return []byte(name), nil
+	}
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Severity) UnmarshalText(buf []byte) error {
+	parsed, ok := ParseSeverity(string(buf))
+	if !ok {
+		if i, err := strconv.Atoi(string(buf)); err == nil {
+			*s = Severity(i)
+			return nil
+		}
+		return Errorf("unknown severity: %q", A(string(buf)), Skip())
+	}
+	*s = parsed
+	return nil
+}
+
+// LogLevel returns the numeric log level corresponding to the severity.
+// Levels match the ones used by log/slog (debug: -4, info: 0, warn: 4, error: 8), with critical mapped to 12.
+func (s Severity) LogLevel() int {
+	return severityLogLevels[s]
+}
+
+// Apply implements the Option interface.
+func (s Severity) Apply(err error) {
+	if e, ok := err.(*wrappedError); ok {
+		e.severity = s
+	}
+}
+
+// ParseSeverity parses a severity from its string representation (case-insensitive), "warn" is accepted as well.
+func ParseSeverity(s string) (Severity, bool) {
+	s = strings.ToLower(s)
+	if s == "warn" {
+		return SeverityWarning, true
+	}
+	for severity, name := range severityNames {
+		if name == s {
+			return severity, true
+		}
+	}
+	return 0, false
+}
+
+// GetSeverity gets the severity from the error. If not set, it falls back to the namespace defaults for its id, and
+// then to a default derived from its status (see SeverityFromStatus).
+func GetSeverity(err error) Severity {
+	if severity, ok := GetExplicitSeverity(err); ok {
+		return severity
+	}
+	return SeverityFromStatus(GetStatus(err))
+}
+
+// GetExplicitSeverity gets the severity set on the error or, if not set, the namespace defaults for its id. Unlike
+// GetSeverity, it does not fall back to a default derived from the status, and returns false if no severity is found.
+func GetExplicitSeverity(err error) (Severity, bool) {
+	if e, ok := err.(*wrappedError); ok {
+		if e.severity != 0 {
+			return e.severity, true
+		}
+		if severity := GetNamespaceDefaults(GetID(e)).Severity; severity != 0 {
+			return severity, true
+		}
+	}
+	return 0, false
+}
+
+// SeverityFromStatus returns the default severity for the given status: info for 1xx to 3xx, warning for 4xx, and
+// error for 5xx or unknown statuses.
+func SeverityFromStatus(status Status) Severity {
+	switch {
+	case status >= http.StatusContinue && status < http.StatusBadRequest:
+		return SeverityInfo
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		return SeverityWarning
+	default:
+		return SeverityError
+	}
+}
@@ -0,0 +1,94 @@
+package errorz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestSeverity(t *testing.T) {
+	require.Equal(t, errorz.SeverityError, errorz.GetSeverity(errorz.Errorf("test error")))
+	require.Equal(t, errorz.SeverityError, errorz.GetSeverity(fmt.Errorf("test error")))
+	require.Equal(t, errorz.SeverityWarning, errorz.GetSeverity(errorz.Errorf("test error", errorz.Status(http.StatusNotFound))))
+	require.Equal(t, errorz.SeverityInfo, errorz.GetSeverity(errorz.Errorf("test error", errorz.Status(http.StatusFound))))
+	require.Equal(t, errorz.SeverityCritical, errorz.GetSeverity(errorz.Errorf("test error", errorz.Status(http.StatusNotFound), errorz.SeverityCritical)))
+
+	errorz.SetNamespaceDefaults("severity-test", errorz.NamespaceDefaults{Severity: errorz.SeverityDebug})
+	defer errorz.ClearNamespaceDefaults("severity-test")
+	require.Equal(t, errorz.SeverityDebug, errorz.GetSeverity(errorz.Errorf("test error", errorz.ID("severity-test.x"), errorz.Status(http.StatusInternalServerError))))
+	require.Equal(t, errorz.SeverityInfo, errorz.GetSeverity(errorz.Errorf("test error", errorz.ID("severity-test.x"), errorz.SeverityInfo)))
+}
+
+func TestGetExplicitSeverity(t *testing.T) {
+	_, ok := errorz.GetExplicitSeverity(fmt.Errorf("test error"))
+	require.False(t, ok)
+
+	_, ok = errorz.GetExplicitSeverity(errorz.Errorf("test error", errorz.Status(http.StatusNotFound)))
+	require.False(t, ok)
+
+	severity, ok := errorz.GetExplicitSeverity(errorz.Errorf("test error", errorz.SeverityInfo))
+	require.True(t, ok)
+	require.Equal(t, errorz.SeverityInfo, severity)
+
+	errorz.SetNamespaceDefaults("explicit-severity-test", errorz.NamespaceDefaults{Severity: errorz.SeverityDebug})
+	defer errorz.ClearNamespaceDefaults("explicit-severity-test")
+	severity, ok = errorz.GetExplicitSeverity(errorz.Errorf("test error", errorz.ID("explicit-severity-test.x")))
+	require.True(t, ok)
+	require.Equal(t, errorz.SeverityDebug, severity)
+}
+
+func TestSeverityFromStatus(t *testing.T) {
+	require.Equal(t, errorz.SeverityError, errorz.SeverityFromStatus(0))
+	require.Equal(t, errorz.SeverityInfo, errorz.SeverityFromStatus(http.StatusContinue))
+	require.Equal(t, errorz.SeverityInfo, errorz.SeverityFromStatus(http.StatusNotModified))
+	require.Equal(t, errorz.SeverityWarning, errorz.SeverityFromStatus(http.StatusBadRequest))
+	require.Equal(t, errorz.SeverityWarning, errorz.SeverityFromStatus(http.StatusTooManyRequests))
+	require.Equal(t, errorz.SeverityError, errorz.SeverityFromStatus(http.StatusInternalServerError))
+	require.Equal(t, errorz.SeverityError, errorz.SeverityFromStatus(1000))
+}
+
+func TestSeverityStrings(t *testing.T) {
+	require.Equal(t, "debug", errorz.SeverityDebug.String())
+	require.Equal(t, "critical", errorz.SeverityCritical.String())
+	require.Equal(t, "", errorz.Severity(0).String())
+
+	for _, s := range []errorz.Severity{errorz.SeverityDebug, errorz.SeverityInfo, errorz.SeverityWarning, errorz.SeverityError, errorz.SeverityCritical} {
+		parsed, ok := errorz.ParseSeverity(s.String())
+		require.True(t, ok)
+		require.Equal(t, s, parsed)
+	}
+
+	parsed, ok := errorz.ParseSeverity("WARN")
+	require.True(t, ok)
+	require.Equal(t, errorz.SeverityWarning, parsed)
+	_, ok = errorz.ParseSeverity("unknown")
+	require.False(t, ok)
+
+	buf, err := json.Marshal(errorz.SeverityWarning)
+	require.NoError(t, err)
+	require.Equal(t, `"warning"`, string(buf))
+	var s errorz.Severity
+	require.NoError(t, json.Unmarshal([]byte(`"critical"`), &s))
+	require.Equal(t, errorz.SeverityCritical, s)
+	require.EqualError(t, json.Unmarshal([]byte(`"unknown"`), &s), `unknown severity: "unknown"`)
+
+	for _, severity := range []errorz.Severity{0, 42, -1} {
+		buf, err = json.Marshal(severity)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(buf, &s))
+		require.Equal(t, severity, s)
+	}
+}
+
+func TestSeverityLogLevel(t *testing.T) {
+	require.Equal(t, -4, errorz.SeverityDebug.LogLevel())
+	require.Equal(t, 0, errorz.SeverityInfo.LogLevel())
+	require.Equal(t, 4, errorz.SeverityWarning.LogLevel())
+	require.Equal(t, 8, errorz.SeverityError.LogLevel())
+	require.Equal(t, 12, errorz.SeverityCritical.LogLevel())
+}
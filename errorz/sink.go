@@ -0,0 +1,107 @@
+package errorz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+var (
+	_ Sink = SinkFunc(nil)
+	_ Sink = &writerSink{}
+	_ Sink = &WebhookSink{}
+)
+
+// Sink receives batches of reported errors (see Reporter).
+// Sinks are called from a single goroutine, and should not retain or modify the summaries.
+type Sink interface {
+	Send(ctx context.Context, summaries []*Summary) error
+}
+
+// SinkFunc implements the Sink interface using a function.
+type SinkFunc func(ctx context.Context, summaries []*Summary) error
+
+// Send implements the Sink interface.
+func (f SinkFunc) Send(ctx context.Context, summaries []*Summary) error {
+	return f(ctx, summaries)
+}
+
+type writerSink struct {
+	m sync.Mutex
+	w io.Writer
+}
+
+// NewWriterSink returns a Sink which writes each summary to the given io.Writer as a line of JSON.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{
+		w: w,
+	}
+}
+
+// Send implements the Sink interface.
+func (s *writerSink) Send(_ context.Context, summaries []*Summary) error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+
+	for _, summary := range summaries {
+		if err := enc.Encode(summary); err != nil {
+			return Wrap(err)
+		}
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	_, err := s.w.Write(buf.Bytes())
+	return MaybeWrap(err)
+}
+
+// WebhookSink is a Sink which POSTs each batch of summaries to a URL as a JSON array.
+// Responses with status other than 2xx are considered failures.
+type WebhookSink struct {
+	// URL is the URL of the webhook.
+	URL string
+	// Client is used to send the requests (default http.DefaultClient).
+	Client *http.Client
+	// Header is added to the requests.
+	Header http.Header
+}
+
+// Send implements the Sink interface.
+func (s *WebhookSink) Send(ctx context.Context, summaries []*Summary) error {
+	buf, err := json.Marshal(summaries)
+	if err != nil {
+		return Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(buf))
+	if err != nil {
+		return Wrap(err)
+	}
+
+	for k, v := range s.Header {
+		req.Header[k] = append([]string{}, v...)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Wrap(err)
+	}
+	defer IgnoreClose(resp.Body)
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Errorf("webhook responded with status %v", A(resp.StatusCode), M("http-url", s.URL), M("http-status", resp.StatusCode))
+	}
+
+	return nil
+}
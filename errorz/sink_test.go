@@ -0,0 +1,81 @@
+package errorz_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestWriterSink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sink := errorz.NewWriterSink(buf)
+
+	require.NoError(t, sink.Send(context.Background(), []*errorz.Summary{
+		errorz.ToSummary(errorz.Errorf("first", errorz.ID("id"))),
+		errorz.ToSummary(fmt.Errorf("second")),
+	}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	s := &errorz.Summary{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), s))
+	require.Equal(t, errorz.ID("id"), s.ID)
+	require.Equal(t, "first", s.Message)
+}
+
+func TestWebhookSink(t *testing.T) {
+	// Requests are checked on the test goroutine, as require must not be called from the handler goroutine.
+	requests := make(chan *http.Request, 2)
+	bodies := make(chan []byte, 2)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		requests <- r
+		bodies <- buf
+
+		if bytes.Count(buf, []byte(`"message"`)) > 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	sink := &errorz.WebhookSink{URL: srv.URL, Header: http.Header{"X-Token": {"secret"}}}
+
+	require.NoError(t, sink.Send(context.Background(), []*errorz.Summary{errorz.ToSummary(fmt.Errorf("first"))}))
+
+	r := <-requests
+	require.Equal(t, http.MethodPost, r.Method)
+	require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	require.Equal(t, "secret", r.Header.Get("X-Token"))
+
+	var received []*errorz.Summary
+	require.NoError(t, json.Unmarshal(<-bodies, &received))
+	require.Len(t, received, 1)
+	require.Equal(t, "first", received[0].Message)
+
+	err := sink.Send(context.Background(), []*errorz.Summary{errorz.ToSummary(fmt.Errorf("first")), errorz.ToSummary(fmt.Errorf("second"))})
+	require.EqualError(t, err, "webhook responded with status 502")
+	require.Equal(t, http.StatusBadGateway, errorz.GetMetadata(err)["http-status"])
+	<-requests
+	require.NoError(t, json.Unmarshal(<-bodies, &received))
+	require.Len(t, received, 2)
+
+	sink = &errorz.WebhookSink{URL: "http://127.0.0.1:0", Client: srv.Client()}
+	require.Error(t, sink.Send(context.Background(), nil))
+}
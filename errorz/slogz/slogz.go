@@ -0,0 +1,151 @@
+// Package slogz provides log/slog integration for errorz errors.
+package slogz
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ slog.Handler = &Handler{}
+)
+
+// HandlerOptions describes the options for Handler.
+type HandlerOptions struct {
+	// AddStack includes stack traces in expanded errors. Each distinct stack trace is output once per record.
+	AddStack bool
+	// KeepLevel disables raising the record level to the level of the most severe error with an explicit severity or
+	// status in it.
+	KeepLevel bool
+}
+
+// Handler is a slog.Handler which expands errors found in attributes (see errorz.LogValue), and raises the level of
+// records to the log level of the most severe error with an explicit severity or status they contain (see
+// errorz.GetExplicitSeverity, errorz.GetExplicitStatus and errorz.Severity.LogLevel). Records are filtered on their
+// own level, so errors never cause otherwise disabled records to be output.
+type Handler struct {
+	next slog.Handler
+	opts HandlerOptions
+}
+
+// NewHandler initializes a new Handler wrapping the given one.
+func NewHandler(next slog.Handler, opts *HandlerOptions) *Handler {
+	h := &Handler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	e := &expander{
+		addStack: h.opts.AddStack,
+		level:    r.Level,
+		stacks:   map[string]struct{}{},
+	}
+
+	expanded := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(e.expandAttr(attr))
+		return true
+	})
+
+	if !h.opts.KeepLevel && e.level > expanded.Level {
+		expanded.Level = e.level
+	}
+
+	return errorz.MaybeWrap(h.next.Handle(ctx, expanded))
+}
+
+// WithAttrs implements the slog.Handler interface.
+// Errors in the given attributes are expanded immediately, without affecting the level of future records.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	e := &expander{
+		addStack: h.opts.AddStack,
+		stacks:   map[string]struct{}{},
+	}
+
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, e.expandAttr(attr))
+	}
+
+	return &Handler{next: h.next.WithAttrs(expanded), opts: h.opts}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+type expander struct {
+	addStack bool
+	level    slog.Level
+	stacks   map[string]struct{}
+}
+
+func (e *expander) expandAttr(attr slog.Attr) slog.Attr {
+	if kind := attr.Value.Kind(); kind == slog.KindAny || kind == slog.KindLogValuer {
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.Attr{Key: attr.Key, Value: e.expandError(err)}
+		}
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		expanded := make([]slog.Attr, 0, len(group))
+		for _, groupAttr := range group {
+			expanded = append(expanded, e.expandAttr(groupAttr))
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	}
+
+	return attr
+}
+
+func (e *expander) expandError(err error) slog.Value {
+	if severity, ok := getExplicitSeverity(err); ok {
+		if level := slog.Level(severity.LogLevel()); level > e.level {
+			e.level = level
+		}
+	}
+
+	value := errorz.LogValue(err, e.addStack)
+	attrs := value.Group()
+	expanded := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		if attr.Key == "stack" {
+			stack, _ := attr.Value.Any().([]string)
+			key := strings.Join(stack, "\n")
+			if _, ok := e.stacks[key]; ok {
+				continue
+			}
+			e.stacks[key] = struct{}{}
+		}
+		expanded = append(expanded, attr)
+	}
+
+	return slog.GroupValue(expanded...)
+}
+
+// getExplicitSeverity returns the severity set on the error, or the one derived from its status if set (see
+// errorz.SeverityFromStatus). Errors with neither, including plain errors, return false.
+func getExplicitSeverity(err error) (errorz.Severity, bool) {
+	if severity, ok := errorz.GetExplicitSeverity(err); ok {
+		return severity, true
+	}
+	if status, ok := errorz.GetExplicitStatus(err); ok {
+		return errorz.SeverityFromStatus(status), true
+	}
+	return 0, false
+}
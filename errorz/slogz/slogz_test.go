@@ -0,0 +1,93 @@
+package slogz_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-errors/errorz/slogz"
+)
+
+func newLogger(opts *slogz.HandlerOptions, level slog.Level) (*slog.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return slog.New(slogz.NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level}), opts)), buf
+}
+
+func decode(buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		record := map[string]interface{}{}
+		errorz.MaybeMustWrap(dec.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestHandler(t *testing.T) {
+	logger, buf := newLogger(nil, slog.LevelInfo)
+	require.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+
+	logger.Info("msg", "err", fmt.Errorf("plain error"), "k", "v")
+	logger.Info("msg", "err", errorz.Errorf("not found", errorz.Status(http.StatusNotFound)))
+	logger.Info("msg", "err", errorz.Errorf("unavailable", errorz.Status(http.StatusServiceUnavailable)))
+	logger.Error("msg", "err", errorz.Errorf("not found", errorz.SeverityWarning))
+	logger.Info("msg", slog.Group("g", slog.Any("err", errorz.Errorf("critical", errorz.SeverityCritical))))
+	logger.Debug("msg", "err", errorz.Errorf("critical", errorz.SeverityCritical))
+	logger.Info("msg", "err1", errorz.Errorf("no status"), "err2", context.DeadlineExceeded)
+
+	records := decode(buf)
+	require.Len(t, records, 6)
+
+	require.Equal(t, "INFO", records[0]["level"])
+	require.Equal(t, map[string]interface{}{"message": "plain error", "severity": "error"}, records[0]["err"])
+	require.Equal(t, "v", records[0]["k"])
+
+	require.Equal(t, "WARN", records[1]["level"])
+	require.Equal(t, map[string]interface{}{"message": "not found", "status": 404.0, "severity": "warning"}, records[1]["err"])
+
+	require.Equal(t, "ERROR", records[2]["level"])
+
+	require.Equal(t, "ERROR", records[3]["level"])
+
+	require.Equal(t, "ERROR+4", records[4]["level"])
+	require.Equal(t, map[string]interface{}{"err": map[string]interface{}{"message": "critical", "severity": "critical"}}, records[4]["g"])
+
+	require.Equal(t, "INFO", records[5]["level"])
+}
+
+func TestHandlerOptions(t *testing.T) {
+	logger, buf := newLogger(&slogz.HandlerOptions{AddStack: true, KeepLevel: true}, slog.LevelInfo)
+	require.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+
+	err := errorz.Errorf("test error", errorz.SeverityCritical)
+	logger.Info("msg", "err1", err, "err2", err, "err3", errorz.Errorf("other error"))
+
+	records := decode(buf)
+	require.Len(t, records, 1)
+	require.Equal(t, "INFO", records[0]["level"])
+	require.Contains(t, records[0]["err1"], "stack")
+	require.NotContains(t, records[0]["err2"], "stack")
+	require.Contains(t, records[0]["err3"], "stack")
+}
+
+func TestHandlerWith(t *testing.T) {
+	logger, buf := newLogger(&slogz.HandlerOptions{AddStack: true}, slog.LevelInfo)
+
+	logger = logger.With("err", errorz.Errorf("test error", errorz.ID("id"))).WithGroup("g")
+	logger.Info("msg", "k", "v")
+
+	records := decode(buf)
+	require.Len(t, records, 1)
+	require.Equal(t, "INFO", records[0]["level"])
+	require.Equal(t, "id", records[0]["err"].(map[string]interface{})["id"])
+	require.Contains(t, records[0]["err"], "stack")
+	require.Equal(t, map[string]interface{}{"k": "v"}, records[0]["g"])
+}
@@ -1,6 +1,7 @@
 package errorz
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -12,6 +13,22 @@ func GetCallers(err error) []uintptr {
 	return getCallersInternal(err, 1)
 }
 
+// FindCallers returns the raw stack trace of the first errorz error with one in the chain (see errors.As), or false if
+// none is found. Unlike GetCallers, it never falls back to the current raw stack trace.
+func FindCallers(err error) ([]uintptr, bool) {
+	for err != nil {
+		var e *wrappedError
+		if !errors.As(err, &e) {
+			return nil, false
+		}
+		if e.callers != nil {
+			return e.callers, true
+		}
+		err = e.err
+	}
+	return nil, false
+}
+
 func getCallersInternal(err error, skip int) []uintptr {
 	if e, ok := err.(*wrappedError); ok {
 		if e.callers != nil {
@@ -74,7 +91,7 @@ func SkipPackage() OptionFunc {
 		if e, ok := err.(*wrappedError); callerPkg != "" && ok && e.callers != nil {
 			otherCallers := make([]uintptr, 0, len(e.callers))
 			for _, caller := range e.callers {
-				if callerPkg != getPackageFromFunc(runtime.FuncForPC(caller)) {
+				if callerPkg != getPackageFromPC(caller) {
 					otherCallers = append(otherCallers, caller)
 				}
 			}
@@ -91,6 +108,11 @@ func getPackageFromFunc(f *runtime.Func) string {
 	return pkg
 }
 
+func getPackageFromPC(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return getPackageFromFuncName(frame.Function)
+}
+
 func getPackageFromFuncName(name string) string {
 	dir := ""
 	base := name
@@ -1,6 +1,7 @@
 package errorz
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -16,3 +17,20 @@ func TestGetPackageFromFuncName(t *testing.T) {
 	require.Equal(t, "a.com/b.c/d", getPackageFromFuncName("a.com/b.c/d.e.f"))
 	require.Equal(t, "a.com/b.c/d", getPackageFromFuncName("a.com/b.c/d.(*e).f"))
 }
+
+func TestFindCallers(t *testing.T) {
+	_, ok := FindCallers(nil)
+	require.False(t, ok)
+
+	_, ok = FindCallers(fmt.Errorf("test error"))
+	require.False(t, ok)
+
+	err := Errorf("test error")
+	callers, ok := FindCallers(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	require.Equal(t, err.(*wrappedError).callers, callers)
+
+	err.(*wrappedError).callers = nil
+	_, ok = FindCallers(err)
+	require.False(t, ok)
+}
@@ -19,10 +19,34 @@ func (s Status) Apply(err error) {
 	}
 }
 
-// GetStatus gets the status code from the error, or 0 if not set.
+// GetStatus gets the status code from the error, falling back to the HTTP status of its canonical code, then to the
+// namespace defaults for its id, then to its classification (see GetClassification), or 0 if not set.
 func GetStatus(err error) Status {
-	if e, ok := err.(*wrappedError); ok {
-		return e.status
+	if status, ok := GetExplicitStatus(err); ok {
+		return status
+	}
+
+	if c, ok := GetClassification(err); ok {
+		return c.Status
 	}
+
 	return 0
 }
+
+// GetExplicitStatus gets the status code set on the error, falling back to the HTTP status of its canonical code, then
+// to the namespace defaults for its id. Unlike GetStatus, it does not fall back to the classification of the error,
+// and returns false if no status is found.
+func GetExplicitStatus(err error) (Status, bool) {
+	if e, ok := err.(*wrappedError); ok {
+		if e.status != 0 {
+			return e.status, true
+		}
+		if e.canonicalCode != nil {
+			return e.canonicalCode.HTTPStatus(), true
+		}
+		if status := GetNamespaceDefaults(GetID(e)).Status; status != 0 {
+			return status, true
+		}
+	}
+	return 0, false
+}
@@ -1,21 +1,34 @@
 package errorz
 
+import (
+	"time"
+)
+
 // Summary provides a serializable summary of an error and its metadata.
 type Summary struct {
-	ID         ID                     `json:"id,omitempty" yaml:"id,omitempty"`
-	Status     Status                 `json:"status,omitempty" yaml:"id,omitempty"`
-	Metadata   map[string]interface{} `json:"metadata,omitempty" yaml:"id,omitempty"`
-	Message    string                 `json:"message,omitempty" yaml:"id,omitempty"`
-	StackTrace []string               `json:"stackTrace,omitempty" yaml:"id,omitempty"`
+	ID          ID                     `json:"id,omitempty" yaml:"id,omitempty"`
+	Fingerprint string                 `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	Code        Code                   `json:"code,omitempty" yaml:"code,omitempty"`
+	Status      Status                 `json:"status,omitempty" yaml:"id,omitempty"`
+	Severity    Severity               `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty" yaml:"id,omitempty"`
+	Message     string                 `json:"message,omitempty" yaml:"id,omitempty"`
+	FieldErrors FieldErrors            `json:"fieldErrors,omitempty" yaml:"fieldErrors,omitempty"`
+	StackTrace  []string               `json:"stackTrace,omitempty" yaml:"id,omitempty"`
+	Time        *time.Time             `json:"time,omitempty" yaml:"time,omitempty"` // set by Reporter.Report
 }
 
 // ToSummary converts an error to Summary.
 func ToSummary(err error) *Summary {
 	return &Summary{
-		ID:         GetID(err),
-		Status:     GetStatus(err),
-		Metadata:   GetMetadata(err),
-		Message:    err.Error(),
-		StackTrace: FormatStackTrace(getCallersInternal(err, 1)),
+		ID:          GetID(err),
+		Fingerprint: Fingerprint(err, nil),
+		Code:        GetCode(err),
+		Status:      GetStatus(err),
+		Severity:    GetSeverity(err),
+		Metadata:    GetMetadata(err),
+		Message:     err.Error(),
+		FieldErrors: GetFieldErrors(err),
+		StackTrace:  FormatStackTrace(getCallersInternal(err, 1)),
 	}
 }
@@ -12,9 +12,11 @@ import (
 )
 
 func TestToSummary(t *testing.T) {
-	s := errorz.ToSummary(errorz.Errorf("some error", errorz.Prefix("prefix"), errorz.ID("id"), errorz.Status(http.StatusUnauthorized)))
+	s := errorz.ToSummary(errorz.Errorf("some error", errorz.Prefix("prefix"), errorz.ID("id"), errorz.Code(10), errorz.Status(http.StatusUnauthorized)))
 	require.Equal(t, errorz.Status(http.StatusUnauthorized), s.Status)
 	require.Equal(t, errorz.ID("id"), s.ID)
+	require.Equal(t, errorz.Code(10), s.Code)
+	require.Equal(t, errorz.SeverityWarning, s.Severity)
 	require.Equal(t, "prefix: some error", s.Message)
 	require.NotEmpty(t, s.StackTrace)
 	require.True(t, strings.HasPrefix(s.StackTrace[0], "errorz_test.TestToSummary"))
@@ -26,7 +28,9 @@ func TestToSummary(t *testing.T) {
 	require.NotEmpty(t, s.StackTrace)
 	require.True(t, strings.HasPrefix(s.StackTrace[0], "errorz_test.TestToSummary"))
 
-	s = errorz.ToSummary(fmt.Errorf("some error"))
+	err := fmt.Errorf("some error")
+	s = errorz.ToSummary(err)
+	require.Equal(t, errorz.Fingerprint(err, nil), s.Fingerprint)
 	require.Equal(t, errorz.Status(0), s.Status)
 	require.Equal(t, errorz.ID(""), s.ID)
 	require.Equal(t, "some error", s.Message)
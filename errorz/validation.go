@@ -0,0 +1,140 @@
+package errorz
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ Option = FieldErrors{}
+)
+
+// InvalidArgumentID is the id of errors returned by Validation.
+const InvalidArgumentID ID = "invalid-argument"
+
+// FieldError describes a validation error on a single field.
+type FieldError struct {
+	// Path is the JSON path of the field (e.g. "items[3].name").
+	Path string `json:"path" yaml:"path"`
+	// Rule is the validation rule that failed (e.g. "required").
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Message is a human-readable description of the error.
+	Message string `json:"message" yaml:"message"`
+}
+
+// String implements the fmt.Stringer interface.
+func (f FieldError) String() string {
+	if f.Path == "" {
+		return f.Message
+	}
+	return f.Path + ": " + f.Message
+}
+
+// FieldErrors describes a list of field errors which can be attached to errors.
+type FieldErrors []FieldError
+
+// Apply implements the Option interface.
+func (f FieldErrors) Apply(err error) {
+	if e, ok := err.(*wrappedError); ok {
+		e.fieldErrors = append(e.fieldErrors, f...)
+	}
+}
+
+// GetFieldErrors gets the field errors from the first error in the chain which has any, nil if not found.
+func GetFieldErrors(err error) FieldErrors {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*wrappedError); ok && len(e.fieldErrors) > 0 {
+			return e.fieldErrors
+		}
+	}
+	return nil
+}
+
+// Validation collects field errors. Nested validations created using Field and Index share the same collection, and
+// prefix the paths of their field errors.
+type Validation struct {
+	path        string
+	fieldErrors *FieldErrors
+}
+
+// NewValidation initializes a new Validation.
+func NewValidation() *Validation {
+	return &Validation{
+		fieldErrors: &FieldErrors{},
+	}
+}
+
+// Field returns a nested validation for the given field.
+func (v *Validation) Field(name string) *Validation {
+	return &Validation{
+		path:        v.join(name),
+		fieldErrors: v.fieldErrors,
+	}
+}
+
+// Index returns a nested validation for the given array index.
+func (v *Validation) Index(i int) *Validation {
+	return &Validation{
+		path:        v.path + "[" + strconv.Itoa(i) + "]",
+		fieldErrors: v.fieldErrors,
+	}
+}
+
+// Add adds a field error. The field is relative to the current validation, use an empty string to refer to it.
+func (v *Validation) Add(field, rule, format string, a ...interface{}) *Validation {
+	*v.fieldErrors = append(*v.fieldErrors, FieldError{
+		Path:    v.join(field),
+		Rule:    rule,
+		Message: fmt.Sprintf(format, a...),
+	})
+	return v
+}
+
+// Check is like Add if cond is false, does nothing otherwise.
+func (v *Validation) Check(cond bool, field, rule, format string, a ...interface{}) *Validation {
+	if !cond {
+		v.Add(field, rule, format, a...)
+	}
+	return v
+}
+
+// HasErrors returns true if any field error has been collected.
+func (v *Validation) HasErrors() bool {
+	return len(*v.fieldErrors) > 0
+}
+
+// FieldErrors returns the collected field errors.
+func (v *Validation) FieldErrors() FieldErrors {
+	return *v.fieldErrors
+}
+
+// Err returns nil if no field error has been collected, otherwise an error with status 400, id "invalid-argument",
+// and the collected field errors, applying the given options.
+func (v *Validation) Err(options ...Option) error {
+	if !v.HasErrors() {
+		return nil
+	}
+
+	fieldErrors := append(FieldErrors{}, *v.fieldErrors...)
+	message := "invalid argument: " + fieldErrors[0].String()
+	if len(fieldErrors) > 1 {
+		message += fmt.Sprintf(" (and %v more)", len(fieldErrors)-1)
+	}
+
+	options = append([]Option{InvalidArgumentID, Status(http.StatusBadRequest), fieldErrors}, options...)
+	options = append(options, Skip())
+	return Wrap(errors.New(message), options...)
+}
+
+func (v *Validation) join(field string) string {
+	switch {
+	case field == "":
+		return v.path
+	case v.path == "":
+		return field
+	default:
+		return v.path + "." + field
+	}
+}
@@ -0,0 +1,67 @@
+package errorz_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+func TestValidation(t *testing.T) {
+	v := errorz.NewValidation()
+	require.False(t, v.HasErrors())
+	require.Nil(t, v.Err())
+	require.Empty(t, v.FieldErrors())
+
+	v.Check(true, "name", "required", "is required")
+	require.False(t, v.HasErrors())
+
+	v.Check(false, "name", "required", "is required")
+	items := v.Field("items")
+	items.Index(3).Add("name", "max-length", "must be at most %v characters", 10)
+	items.Index(4).Field("tags").Index(0).Add("", "format", "is invalid")
+	items.Add("", "", "must not be empty")
+	require.True(t, v.HasErrors())
+	require.True(t, items.HasErrors())
+
+	expected := errorz.FieldErrors{
+		{Path: "name", Rule: "required", Message: "is required"},
+		{Path: "items[3].name", Rule: "max-length", Message: "must be at most 10 characters"},
+		{Path: "items[4].tags[0]", Rule: "format", Message: "is invalid"},
+		{Path: "items", Message: "must not be empty"},
+	}
+	require.Equal(t, expected, v.FieldErrors())
+
+	err := v.Err(errorz.M("k", "v"))
+	require.EqualError(t, err, "invalid argument: name: is required (and 3 more)")
+	require.Equal(t, errorz.InvalidArgumentID, errorz.GetID(err))
+	require.Equal(t, errorz.Status(http.StatusBadRequest), errorz.GetStatus(err))
+	require.Equal(t, errorz.Metadata{"k": "v"}, errorz.GetMetadata(err))
+	require.Equal(t, expected, errorz.GetFieldErrors(err))
+	require.Equal(t, expected, errorz.GetFieldErrors(fmt.Errorf("wrapped: %w", err)))
+
+	err = errorz.NewValidation().Add("", "", "bad request").Err(errorz.ID("custom"))
+	require.EqualError(t, err, "invalid argument: bad request")
+	require.Equal(t, errorz.ID("custom"), errorz.GetID(err))
+}
+
+func TestFieldErrors(t *testing.T) {
+	require.Nil(t, errorz.GetFieldErrors(nil))
+	require.Nil(t, errorz.GetFieldErrors(fmt.Errorf("test error")))
+	require.Nil(t, errorz.GetFieldErrors(errorz.Errorf("test error")))
+
+	err := errorz.Errorf("test error",
+		errorz.FieldErrors{{Path: "a", Message: "bad a"}},
+		errorz.FieldErrors{{Path: "b", Message: "bad b"}})
+	require.Equal(t, errorz.FieldErrors{{Path: "a", Message: "bad a"}, {Path: "b", Message: "bad b"}}, errorz.GetFieldErrors(err))
+
+	s := errorz.ToSummary(err)
+	require.Equal(t, errorz.GetFieldErrors(err), s.FieldErrors)
+	buf, jsonErr := json.Marshal(s.FieldErrors)
+	require.NoError(t, jsonErr)
+	require.JSONEq(t, `[{"path": "a", "message": "bad a"}, {"path": "b", "message": "bad b"}]`, string(buf))
+}
@@ -0,0 +1,240 @@
+// Package loader parses and type-checks Go packages for the errorz commands, using only the standard library.
+package loader
+
+import (
+	"bufio"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+// Package describes a parsed and type-checked package.
+// Type information can be partial, if the package doesn't type-check cleanly.
+type Package struct {
+	Dir   string
+	Path  string
+	Files []*ast.File
+	Types *types.Package
+	Info  *types.Info
+}
+
+// Loader loads packages, sharing the file set and the imported packages.
+type Loader struct {
+	Fset  *token.FileSet
+	Tests bool
+	imp   types.Importer
+}
+
+// New initializes a new Loader. If tests is true, test files are loaded as well.
+func New(tests bool) *Loader {
+	fset := token.NewFileSet()
+	return &Loader{
+		Fset:  fset,
+		Tests: tests,
+		imp:   importer.ForCompiler(fset, "source", nil),
+	}
+}
+
+// ExpandPatterns converts the given patterns to a list of directories. Patterns are directories, with the "/..."
+// suffix for recursive matching (skipping "testdata", "vendor", and hidden directories).
+func ExpandPatterns(patterns []string) ([]string, error) {
+	var dirs []string
+
+	for _, pattern := range patterns {
+		if !strings.HasSuffix(pattern, "/...") {
+			dirs = append(dirs, pattern)
+			continue
+		}
+
+		root := strings.TrimSuffix(pattern, "/...")
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errorz.Wrap(err, errorz.M("pattern", pattern))
+		}
+	}
+
+	return dirs, nil
+}
+
+// LoadDir parses and type-checks the Go packages in the given directory. Files excluded by build constraints for the
+// current platform are skipped. External test packages ("_test" suffix) are returned separately from the package
+// under test. Packages are type-checked using their import path, derived from the enclosing go.mod file, so that they
+// are recognized when referring to themselves.
+func (l *Loader) LoadDir(dir string) ([]*Package, error) {
+	pkgs, err := l.parseDir(dir)
+	if err != nil {
+		return nil, errorz.Wrap(err, errorz.M("dir", dir))
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errorz.Wrap(err, errorz.M("dir", dir))
+	}
+
+	importPath, err := getImportPath(absDir)
+	if err != nil {
+		return nil, errorz.Wrap(err, errorz.M("dir", dir))
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	loaded := make([]*Package, 0, len(names))
+
+	for _, name := range names {
+		files := pkgs[name]
+
+		info := &types.Info{
+			Types: map[ast.Expr]types.TypeAndValue{},
+			Defs:  map[*ast.Ident]types.Object{},
+			Uses:  map[*ast.Ident]types.Object{},
+		}
+
+		cfg := &types.Config{
+			Importer: &dirImporter{imp: l.imp, dir: absDir},
+			Error:    func(error) {}, // keep going with partial type information
+		}
+
+		pkgPath := importPath
+		if strings.HasSuffix(name, "_test") {
+			pkgPath += "_test"
+		}
+
+		pkg, _ := cfg.Check(pkgPath, l.Fset, files, info)
+
+		loaded = append(loaded, &Package{
+			Dir:   dir,
+			Path:  pkgPath,
+			Files: files,
+			Types: pkg,
+			Info:  info,
+		})
+	}
+
+	return loaded, nil
+}
+
+// parseDir parses the Go files in the given directory which match the build constraints for the current platform,
+// grouping them by package name. Files are sorted by name.
+func (l *Loader) parseDir(dir string) (map[string][]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errorz.Wrap(err)
+	}
+
+	pkgs := map[string][]*ast.File{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || (!l.Tests && strings.HasSuffix(name, "_test.go")) {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil {
+			return nil, errorz.Wrap(err, errorz.M("file", name))
+		} else if !match {
+			continue
+		}
+
+		file, err := parser.ParseFile(l.Fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, errorz.Wrap(err, errorz.M("file", name))
+		}
+
+		pkgs[file.Name.Name] = append(pkgs[file.Name.Name], file)
+	}
+
+	return pkgs, nil
+}
+
+// getImportPath returns the import path of the package in the given absolute directory, based on the module path
+// declared in the closest go.mod file. It falls back to the directory name outside of modules.
+func getImportPath(absDir string) (string, error) {
+	for dir := absDir; ; dir = filepath.Dir(dir) {
+		modulePath, err := readModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return "", errorz.Wrap(err)
+		}
+
+		if modulePath != "" {
+			rel, err := filepath.Rel(dir, absDir)
+			if err != nil {
+				return "", errorz.Wrap(err)
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+
+		if filepath.Dir(dir) == dir {
+			return filepath.Base(absDir), nil
+		}
+	}
+}
+
+// readModulePath returns the module path declared in the given go.mod file, or an empty string if it doesn't exist.
+func readModulePath(goModPath string) (string, error) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errorz.Wrap(err)
+	}
+	defer errorz.IgnoreClose(f)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", errorz.Wrap(err, errorz.M("path", goModPath))
+	}
+
+	return "", errorz.Errorf("go.mod file has no module directive", errorz.M("path", goModPath))
+}
+
+// dirImporter resolves imports relative to a directory, so that module dependencies can be found.
+type dirImporter struct {
+	imp types.Importer
+	dir string
+}
+
+// Import implements the types.Importer interface.
+func (i *dirImporter) Import(path string) (*types.Package, error) {
+	return i.ImportFrom(path, i.dir, 0)
+}
+
+// ImportFrom implements the types.ImporterFrom interface.
+func (i *dirImporter) ImportFrom(path, _ string, mode types.ImportMode) (*types.Package, error) {
+	if from, ok := i.imp.(types.ImporterFrom); ok {
+		return from.ImportFrom(path, i.dir, mode)
+	}
+	return i.imp.Import(path)
+}
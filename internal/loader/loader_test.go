@@ -0,0 +1,46 @@
+package loader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-errors/internal/loader"
+)
+
+func TestLoadDir(t *testing.T) {
+	pkgs, err := loader.New(true).LoadDir("../../errorz/slogz")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	require.Equal(t, "github.com/ibrt/golang-errors/errorz/slogz", pkgs[0].Path)
+	require.Equal(t, "github.com/ibrt/golang-errors/errorz/slogz", pkgs[0].Types.Path())
+	require.Equal(t, "github.com/ibrt/golang-errors/errorz/slogz_test", pkgs[1].Path)
+
+	pkgs, err = loader.New(false).LoadDir("../../errorz/slogz")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	pkgs, err = loader.New(false).LoadDir("testdata/constraints")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "constraints", pkgs[0].Types.Name())
+	require.Len(t, pkgs[0].Files, 1)
+	require.NotNil(t, pkgs[0].Types.Scope().Lookup("A"))
+
+	_, err = loader.New(false).LoadDir("./does-not-exist")
+	require.Error(t, err)
+}
+
+func TestExpandPatterns(t *testing.T) {
+	dirs, err := loader.ExpandPatterns([]string{"../../cmd/...", "."})
+	require.NoError(t, err)
+	require.Equal(t, []string{"../../cmd", "../../cmd/errorzaudit", "../../cmd/errorzvet", "."}, dirs)
+
+	dirs, err = loader.ExpandPatterns([]string{"../../..."})
+	require.NoError(t, err)
+	require.Contains(t, dirs, "../../errorz/slogz")
+	require.NotContains(t, dirs, "../../cmd/errorzvet/testdata/a")
+
+	_, err = loader.ExpandPatterns([]string{"./does-not-exist/..."})
+	require.Error(t, err)
+}
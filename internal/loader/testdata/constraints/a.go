@@ -0,0 +1,3 @@
+package constraints
+
+func A() {}
@@ -0,0 +1,5 @@
+//go:build never
+
+package constraints
+
+func A() {}